@@ -43,13 +43,41 @@ func Backend(c *logical.BackendConfig) *pgate.Backend {
 		BackendType:    logical.TypeLogical,
 		Help:           "[PolicyGate] Vault/OpenBao Plugin for conditional access to Policies",
 		RunningVersion: Version,
+		PathsSpecial: &logical.Paths{
+			SealWrapStorage: base.SealWrappedPaths,
+		},
 		Paths: []*framework.Path{
 			// Provided by Base package
 			base.PathConfig(&baseBackend),
 			base.PathConfigLease(&baseBackend),
+			base.PathConfigBundle(&baseBackend),
+			base.PathConfigBundleReset(&baseBackend),
+			base.PathConfigHistory(&baseBackend),
+			base.PathConfigRollback(&baseBackend),
+			base.PathConfigProposal(&baseBackend),
+			base.PathConfigProposalApprove(&baseBackend),
+			base.PathHealth(&baseBackend),
+			base.PathReconcile(&baseBackend),
+			base.PathRevocationsPending(&baseBackend),
+			base.PathRevocationsRetry(&baseBackend),
+			base.PathStats(&baseBackend),
+			base.PathMigrations(&baseBackend),
+			base.PathRequestKeys(&baseBackend),
+			base.PathRequestKeysRotate(&baseBackend),
 
 			base.PathRequest(&baseBackend),
+			base.PathRequestComments(&baseBackend),
 			base.PathApprove(&baseBackend),
+			base.PathApproveBulk(&baseBackend),
+			base.PathReject(&baseBackend),
+			base.PathRejectBulk(&baseBackend),
+			base.PathInbox(&baseBackend),
+			base.PathActive(&baseBackend),
+			base.PathRevoke(&baseBackend),
+			base.PathRelease(&baseBackend),
+			base.PathFreeze(&baseBackend),
+			base.PathUnfreeze(&baseBackend),
+			base.PathKill(&baseBackend),
 			base.PathClaim(&baseBackend),
 
 			// Provided by Policy Gate
@@ -59,6 +87,9 @@ func Backend(c *logical.BackendConfig) *pgate.Backend {
 		Secrets: []*framework.Secret{
 			base.ClaimSecret(&baseBackend),
 		},
+		PeriodicFunc: baseBackend.Periodic,
+		// Rolls back claims left without a lease
+		WALRollback: baseBackend.WALRollback,
 	}
 
 	bFinal.Logger().Debug("Plugin initialized")
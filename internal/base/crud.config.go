@@ -11,9 +11,11 @@
 package base
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/vault/sdk/logical"
 )
@@ -53,11 +55,11 @@ func GetConfigurationFromStorage[T PluginConfig](ctx context.Context, b *BaseBac
 }
 
 func StoreConfigurationToStorage[T PluginConfig](ctx context.Context, b *BaseBackend, storage logical.Storage, config T, path string) error {
-	configJSON, err := json.Marshal(config)
+	configJSON, err := marshalVersionedConfiguration(config)
 	if err != nil {
 		b.Logger().Error("[-] Could not marshal configuration to JSON",
 			"path", path,
-			"config", config,
+			"config", RedactConfiguration(config),
 			"error", err,
 		)
 		return err
@@ -69,8 +71,7 @@ func StoreConfigurationToStorage[T PluginConfig](ctx context.Context, b *BaseBac
 	if err != nil {
 		b.Logger().Error("[-] Could not store configuration to storage",
 			"path", path,
-			"config", config,
-			"configJSON", configJSON,
+			"config", RedactConfiguration(config),
 			"error", err,
 		)
 		return err
@@ -89,12 +90,104 @@ func StoreConfiguration[T PluginConfig](ctx context.Context, b *BaseBackend, req
 	return StoreConfigurationToStorage(ctx, b, req.Storage, config, configStorageKey)
 }
 
+// StoreConfigurationsToStorage stores all 'configs' (by storage path) or none of them,
+// restoring the previously stored entries if a write fails.
+// Stored configurations are recorded in their history, changed by 'entityID'.
+// The caller must hold 'ConfigMutex'.
+func (b *BaseBackend) StoreConfigurationsToStorage(ctx context.Context, storage logical.Storage, configs map[string]PluginConfig, entityID string) error {
+	return b.storeConfigurationVersions(ctx, storage, configs, entityID, 0)
+}
+
+// storeConfigurationVersions stores all 'configs' along with their new version in their history,
+// or none of them, restoring the previous configurations and histories if a write fails.
+func (b *BaseBackend) storeConfigurationVersions(ctx context.Context, storage logical.Storage, configs map[string]PluginConfig, entityID string, rollbackOf int) error {
+	paths := make([]string, 0, len(configs))
+	for path := range configs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// Both the configurations and their histories are restored on failure
+	keys := []string{}
+	previousEntries := map[string]*logical.StorageEntry{}
+	for _, path := range paths {
+		for _, key := range []string{path, storageKeyForConfigHistory(path)} {
+			entry, err := storage.Get(ctx, key)
+			if err != nil {
+				return fmt.Errorf("Could not retrieve configuration from BaseBackend")
+			}
+			keys = append(keys, key)
+			previousEntries[key] = entry
+		}
+	}
+
+	for _, path := range paths {
+		err := StoreConfigurationToStorage(ctx, b, storage, configs[path], path)
+		if err != nil {
+			b.restoreStorageEntries(ctx, storage, keys, previousEntries)
+			return fmt.Errorf("could not store configuration '%s', no configuration was changed: %w", path, err)
+		}
+
+		var previousValue []byte
+		if previousEntries[path] != nil {
+			previousValue = previousEntries[path].Value
+		}
+		err = b.recordConfigVersion(ctx, storage, path, previousValue, configs[path], entityID, rollbackOf)
+		if err != nil {
+			b.restoreStorageEntries(ctx, storage, keys, previousEntries)
+			return fmt.Errorf("could not record configuration '%s' in its history, no configuration was changed: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// restoreStorageEntries puts back the 'previousEntries' of 'keys', deleting the ones that did not exist
+func (b *BaseBackend) restoreStorageEntries(ctx context.Context, storage logical.Storage, keys []string, previousEntries map[string]*logical.StorageEntry) {
+	for _, key := range keys {
+		var restoreErr error
+		if previousEntries[key] == nil {
+			restoreErr = storage.Delete(ctx, key)
+		} else {
+			restoreErr = storage.Put(ctx, previousEntries[key])
+		}
+		if restoreErr != nil {
+			b.Logger().Error("[!] Could not restore configuration after a failed write",
+				"path", key,
+				"error", restoreErr,
+			)
+		}
+	}
+}
+
 func StoreConfigurationToStorageIfNotPresent[T PluginConfig](ctx context.Context, b *BaseBackend, storage logical.Storage, config T, path string) (bool, error) {
 	// var zero T
 
 	_, err := GetConfigurationFromStorage[T](ctx, b, storage, path)
 	if err != nil {
+		// Storage is read-only on performance standbys and secondaries,
+		// the defaults are written by the active node of the primary
+		if !b.WriteSafeReplicationState() {
+			return false, nil
+		}
 		return false, StoreConfigurationToStorage[T](ctx, b, storage, config, path)
 	}
 	return true, nil
 }
+
+// marshalVersionedConfiguration adds the configuration schema version to the JSON of 'config'
+func marshalVersionedConfiguration(config interface{}) ([]byte, error) {
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode numbers as json.Number to keep durations (nanoseconds) exact
+	var configMap map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(configJSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&configMap); err != nil {
+		return nil, err
+	}
+	configMap[ConfigSchemaVersionKey] = ConfigSchemaVersion
+	return json.Marshal(configMap)
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+/*
+	Every configuration written through the API is recorded as a new version
+	in the ConfigHistory of its path, stored under 'ConfigHistoryKeyPrefix'
+	(seal-wrapped, as it contains the API credentials).
+
+	The configuration stored before the first recorded change
+	(e.g: the defaults set on 'Initialize') is version 1.
+*/
+
+// Number of versions kept in the history of each configuration.
+// Older versions are dropped, and cannot be rolled back to.
+const ConfigHistorySize = 10
+
+type ConfigVersion struct {
+	Version   int       `json:"version"`
+	EntityID  string    `json:"entity_id"`
+	CreatedAt time.Time `json:"iat"`
+	// Version this one restored, if it is a rollback
+	RollbackOf int `json:"rollback_of,omitempty"`
+
+	// The stored configuration JSON
+	Config json.RawMessage `json:"config"`
+}
+
+type ConfigHistory struct {
+	CurrentVersion int `json:"current_version"`
+	// The last 'ConfigHistorySize' versions, oldest first
+	Versions []ConfigVersion `json:"versions"`
+}
+
+func storageKeyForConfigHistory(path string) string {
+	return ConfigHistoryKeyPrefix + path
+}
+
+func (b *BaseBackend) GetConfigHistoryFromStorage(ctx context.Context, storage logical.Storage, path string) (*ConfigHistory, error) {
+	entry, err := storage.Get(ctx, storageKeyForConfigHistory(path))
+	if err != nil {
+		b.Logger().Error("[-] Could not retrieve configuration history from storage",
+			"path", path,
+			"error", err,
+		)
+		return nil, fmt.Errorf("Could not retrieve configuration history from BaseBackend")
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var history ConfigHistory
+	if err := json.Unmarshal(entry.Value, &history); err != nil {
+		b.Logger().Error("[-] Failed to unmarshal ConfigHistory",
+			"path", path,
+			"error", err,
+		)
+		return nil, fmt.Errorf("Configuration history could not be retrieved")
+	}
+	return &history, nil
+}
+
+// ConfigVersionOf returns the current version of the configuration at 'path'
+func (b *BaseBackend) ConfigVersionOf(ctx context.Context, storage logical.Storage, path string) (int, error) {
+	history, err := b.GetConfigHistoryFromStorage(ctx, storage, path)
+	if err != nil {
+		return 0, err
+	}
+	if history == nil {
+		return 1, nil
+	}
+	return history.CurrentVersion, nil
+}
+
+// recordConfigVersion appends 'config' to the history of 'path', after the
+// 'previousValue' that it replaced in storage (used as version 1 if there is no history).
+// The caller must hold 'ConfigMutex'.
+func (b *BaseBackend) recordConfigVersion(ctx context.Context, storage logical.Storage, path string, previousValue []byte, config PluginConfig, entityID string, rollbackOf int) error {
+	history, err := b.GetConfigHistoryFromStorage(ctx, storage, path)
+	if err != nil {
+		return err
+	}
+	if history == nil {
+		history = &ConfigHistory{}
+		if previousValue != nil {
+			history.CurrentVersion = 1
+			history.Versions = append(history.Versions, ConfigVersion{
+				Version:   1,
+				CreatedAt: time.Now(),
+				Config:    previousValue,
+			})
+		}
+	}
+
+	configJSON, err := marshalVersionedConfiguration(config)
+	if err != nil {
+		return err
+	}
+	history.CurrentVersion++
+	history.Versions = append(history.Versions, ConfigVersion{
+		Version:    history.CurrentVersion,
+		EntityID:   entityID,
+		CreatedAt:  time.Now(),
+		RollbackOf: rollbackOf,
+		Config:     configJSON,
+	})
+	if len(history.Versions) > ConfigHistorySize {
+		history.Versions = history.Versions[len(history.Versions)-ConfigHistorySize:]
+	}
+
+	historyJSON, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	return storage.Put(ctx, &logical.StorageEntry{
+		Key:      storageKeyForConfigHistory(path),
+		Value:    historyJSON,
+		SealWrap: true,
+	})
+}
+
+// StoreConfigurationVersion stores 'config' under 'path' and records it as a new version
+// in its history, changed by 'entityID'. The caller must hold 'ConfigMutex'.
+func (b *BaseBackend) StoreConfigurationVersion(ctx context.Context, storage logical.Storage, config PluginConfig, path string, entityID string) error {
+	return b.storeConfigurationVersion(ctx, storage, config, path, entityID, 0)
+}
+
+func (b *BaseBackend) storeConfigurationVersion(ctx context.Context, storage logical.Storage, config PluginConfig, path string, entityID string, rollbackOf int) error {
+	return b.storeConfigurationVersions(ctx, storage, map[string]PluginConfig{path: config}, entityID, rollbackOf)
+}
+
+// configVersionFromHistory returns the configuration of 'section' at 'version'
+func configVersionFromHistory(history *ConfigHistory, section ConfigSection, version int) (PluginConfig, error) {
+	if history != nil {
+		for _, configVersion := range history.Versions {
+			if configVersion.Version != version {
+				continue
+			}
+			config := section.Default()
+			if err := json.Unmarshal(configVersion.Config, config); err != nil {
+				return nil, err
+			}
+			return config, nil
+		}
+	}
+	return nil, &ConfigValidationError{
+		Key:    "version",
+		Reason: fmt.Sprintf("version %d of '%s' is not in its history", version, section.Key),
+	}
+}
+
+// currentConfigVersions returns the current version of every configuration
+func (b *BaseBackend) currentConfigVersions(ctx context.Context, storage logical.Storage) (map[string]int, error) {
+	versions := map[string]int{}
+	for _, section := range b.ConfigSections {
+		version, err := b.ConfigVersionOf(ctx, storage, section.Key)
+		if err != nil {
+			return nil, err
+		}
+		versions[section.Key] = version
+	}
+	return versions, nil
+}
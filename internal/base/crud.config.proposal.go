@@ -0,0 +1,282 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+/* ======================== CRUD ConfigProposal
+ConfigProposals are guarded by 'ConfigMutex', along with the configurations.
+*/
+
+func storageKeyForConfigProposal(proposalID string) string {
+	return ConfigProposalKeyPrefix + proposalID
+}
+
+func (b *BaseBackend) GetConfigProposalFromStorage(ctx context.Context, storage logical.Storage, proposalID string) (*ConfigProposal, error) {
+	entry, err := storage.Get(ctx, storageKeyForConfigProposal(proposalID))
+	if err != nil {
+		b.Logger().Error("[-] Could not retrieve ConfigProposal from storage",
+			"ProposalID", proposalID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("Could not retrieve ConfigProposal from BaseBackend")
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var proposal ConfigProposal
+	if err := json.Unmarshal(entry.Value, &proposal); err != nil {
+		b.Logger().Error("[-] Failed to unmarshal ConfigProposal",
+			"ProposalID", proposalID,
+			"error", err,
+		)
+		return nil, fmt.Errorf("ConfigProposal could not be retrieved")
+	}
+
+	if proposal.Status == models.Pending && proposal.Expiration.Before(time.Now()) {
+		proposal.Status = models.Abandoned
+	}
+	return &proposal, nil
+}
+
+func (b *BaseBackend) storeConfigProposalToStorage(ctx context.Context, storage logical.Storage, proposal *ConfigProposal) error {
+	proposalJSON, err := json.Marshal(proposal)
+	if err != nil {
+		return err
+	}
+	err = storage.Put(ctx, &logical.StorageEntry{
+		Key:      storageKeyForConfigProposal(proposal.ID),
+		Value:    proposalJSON,
+		SealWrap: true,
+	})
+	if err != nil {
+		b.Logger().Error("[-] Could not store ConfigProposal to storage",
+			"ProposalID", proposal.ID,
+			"error", err,
+		)
+		return fmt.Errorf("Could not store ConfigProposal")
+	}
+	return nil
+}
+
+func (b *BaseBackend) ListConfigProposals(ctx context.Context, storage logical.Storage) ([]*ConfigProposal, error) {
+	proposalIDs, err := storage.List(ctx, ConfigProposalKeyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("Could not list ConfigProposals")
+	}
+
+	proposals := []*ConfigProposal{}
+	for _, proposalID := range proposalIDs {
+		proposal, err := b.GetConfigProposalFromStorage(ctx, storage, proposalID)
+		if err != nil {
+			return nil, err
+		}
+		if proposal != nil {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals, nil
+}
+
+func (b *BaseBackend) DeleteConfigProposalFromStorage(ctx context.Context, storage logical.Storage, proposalID string) error {
+	return storage.Delete(ctx, storageKeyForConfigProposal(proposalID))
+}
+
+// configProtection returns the plugin configuration if configuration writes require approval, or nil.
+// The caller must hold 'ConfigMutex'.
+func (b *BaseBackend) configProtection(ctx context.Context, storage logical.Storage) (*Config, error) {
+	config, err := GetConfigurationFromStorage[*Config](ctx, b, storage, ConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if !config.ProtectConfig {
+		return nil, nil
+	}
+	return config, nil
+}
+
+// isConfigApprover returns whether 'entityID' is listed in the 'config_approvers' of 'config',
+// or is a member of an identity group listed in it
+func (b *BaseBackend) isConfigApprover(config *Config, entityID string) (bool, error) {
+	for _, approver := range config.ConfigApprovers {
+		if approver == entityID {
+			return true, nil
+		}
+	}
+
+	groups, err := b.System().GroupsForEntity(entityID)
+	if err != nil {
+		return false, err
+	}
+	for _, group := range groups {
+		for _, approver := range config.ConfigApprovers {
+			if group.Name == approver || group.ID == approver {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// proposeConfigurations creates a ConfigProposal for storing the validated 'configs' (by path).
+// The caller must hold 'ConfigMutex'.
+func (b *BaseBackend) proposeConfigurations(ctx context.Context, req *logical.Request, protection *Config, configs map[string]PluginConfig, diff map[string]map[string]responses.ConfigChangeResponse) (*ConfigProposal, error) {
+	if req.EntityID == "" {
+		return nil, fmt.Errorf("The configuration is protected and can only be changed by Tokens with an EntityID assigned")
+	}
+	proposalID, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	proposal := &ConfigProposal{
+		ID:         proposalID,
+		OwnerID:    req.EntityID,
+		CreatedAt:  now,
+		Expiration: now.Add(protection.RequestTTL),
+		Deletion:   now.Add(protection.DeleteAfter),
+
+		Status:            models.Pending,
+		RequiredApprovals: protection.ConfigRequiredApprovals,
+		Approvals:         map[string]*Approval{},
+
+		Configs:      map[string]json.RawMessage{},
+		Diff:         diff,
+		BaseVersions: map[string]int{},
+	}
+	for path, config := range configs {
+		configJSON, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		proposal.Configs[path] = configJSON
+
+		proposal.BaseVersions[path], err = b.ConfigVersionOf(ctx, req.Storage, path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.storeConfigProposalToStorage(ctx, req.Storage, proposal); err != nil {
+		return nil, err
+	}
+	b.Logger().Info("[+] Configuration change proposed",
+		"ProposalID", proposal.ID,
+		"EntityID", req.EntityID,
+		"Diff", proposal.Diff,
+	)
+	return proposal, nil
+}
+
+// applyConfigProposal stores the configurations of an approved ConfigProposal, returning them.
+// If they changed since the ConfigProposal was created, it is 'rejected' instead.
+// The caller must hold 'ConfigMutex'.
+func (b *BaseBackend) applyConfigProposal(ctx context.Context, storage logical.Storage, proposal *ConfigProposal) (map[string]PluginConfig, error) {
+	configs := map[string]PluginConfig{}
+	candidates := []PluginConfig{}
+	var applyErr error
+
+	for path, configJSON := range proposal.Configs {
+		version, err := b.ConfigVersionOf(ctx, storage, path)
+		if err != nil {
+			return nil, err
+		}
+		if version != proposal.BaseVersions[path] {
+			applyErr = fmt.Errorf("the configuration '%s' changed since the ConfigProposal was created (version %d, now %d)",
+				path, proposal.BaseVersions[path], version,
+			)
+			break
+		}
+
+		section, ok := b.configSection(path)
+		if !ok {
+			return nil, &UnknownConfigKeyError{Key: path}
+		}
+		config := section.Default()
+		if err := json.Unmarshal(configJSON, config); err != nil {
+			return nil, err
+		}
+		configs[path] = config
+		candidates = append(candidates, config)
+	}
+	if applyErr == nil {
+		applyErr = b.validateConfigurationConsistency(ctx, storage, candidates...)
+	}
+
+	if applyErr != nil {
+		proposal.Status = models.Rejected
+		if err := b.storeConfigProposalToStorage(ctx, storage, proposal); err != nil {
+			return nil, err
+		}
+		return nil, applyErr
+	}
+
+	if err := b.StoreConfigurationsToStorage(ctx, storage, configs, proposal.OwnerID); err != nil {
+		return nil, err
+	}
+	proposal.Status = models.Approved
+	if err := b.storeConfigProposalToStorage(ctx, storage, proposal); err != nil {
+		return nil, err
+	}
+
+	b.Logger().Warn("[+] Configuration change applied after approval",
+		"ProposalID", proposal.ID,
+		"EntityID", proposal.OwnerID,
+		"Approvals", len(proposal.Approvals),
+	)
+	return configs, nil
+}
+
+// TidyConfigProposals deletes the ConfigProposals past their deletion time
+func (b *BaseBackend) TidyConfigProposals(ctx context.Context, storage logical.Storage) error {
+	b.ConfigMutex.Lock()
+	defer b.ConfigMutex.Unlock()
+
+	proposals, err := b.ListConfigProposals(ctx, storage)
+	if err != nil {
+		return err
+	}
+	for _, proposal := range proposals {
+		if proposal.Deletion.After(time.Now()) {
+			continue
+		}
+		if err := b.DeleteConfigProposalFromStorage(ctx, storage, proposal.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeOrProposeConfigurations stores the 'configs' (by path), or proposes them if the configuration is protected.
+// The caller must hold 'ConfigMutex'.
+func (b *BaseBackend) storeOrProposeConfigurations(ctx context.Context, req *logical.Request, configs map[string]PluginConfig, diff map[string]map[string]responses.ConfigChangeResponse) (*ConfigProposal, error) {
+	protection, err := b.configProtection(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if protection != nil && len(configs) > 0 {
+		return b.proposeConfigurations(ctx, req, protection, configs, diff)
+	}
+	return nil, b.StoreConfigurationsToStorage(ctx, req.Storage, configs, req.EntityID)
+}
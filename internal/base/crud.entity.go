@@ -0,0 +1,135 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+/* ======================== Entities
+The requestors and approvers are resolved through the SystemView,
+so that responses carry their names alongside their IDs.
+*/
+
+// How long resolved entities are kept, before being resolved again
+const EntityCacheTTL = 1 * time.Minute
+
+type cachedEntity struct {
+	// nil if the entity could not be resolved
+	Entity    *logical.Entity
+	ExpiresAt time.Time
+}
+
+// entityInfo returns the entity of 'entityID' through the cache, or nil if it cannot be resolved
+func (b *BaseBackend) entityInfo(entityID string) *logical.Entity {
+	b.entityCacheMutex.Lock()
+	cached, ok := b.entityCache[entityID]
+	b.entityCacheMutex.Unlock()
+	if ok && time.Now().Before(cached.ExpiresAt) {
+		return cached.Entity
+	}
+
+	// Resolved without the lock, so a slow identity store does not block other lookups
+	entity, err := b.System().EntityInfo(entityID)
+	if err != nil {
+		b.Logger().Warn("[!] Could not resolve entity",
+			"EntityID", entityID,
+			"error", err,
+		)
+		entity = nil
+	}
+
+	b.entityCacheMutex.Lock()
+	defer b.entityCacheMutex.Unlock()
+
+	now := time.Now()
+	// Another lookup may have resolved the entity meanwhile
+	if cached, ok := b.entityCache[entityID]; ok && now.Before(cached.ExpiresAt) {
+		return cached.Entity
+	}
+	if b.entityCache == nil {
+		b.entityCache = map[string]cachedEntity{}
+	}
+	// Expired entries are swept at most once per 'EntityCacheTTL'
+	if now.Sub(b.entityCacheEvictedAt) >= EntityCacheTTL {
+		for id, cached := range b.entityCache {
+			if !now.Before(cached.ExpiresAt) {
+				delete(b.entityCache, id)
+			}
+		}
+		b.entityCacheEvictedAt = now
+	}
+	b.entityCache[entityID] = cachedEntity{
+		Entity:    entity,
+		ExpiresAt: now.Add(EntityCacheTTL),
+	}
+	return entity
+}
+
+// entityResolver resolves entity IDs for the responses of a single request
+type entityResolver struct {
+	b            *BaseBackend
+	metadataKeys []string
+}
+
+// newEntityResolver returns an entityResolver returning the metadata under 'entity_metadata_keys'
+func (b *BaseBackend) newEntityResolver(ctx context.Context, req *logical.Request) entityResolver {
+	b.ConfigMutex.RLock()
+	defer b.ConfigMutex.RUnlock()
+
+	resolver := entityResolver{b: b}
+	config, err := GetConfigurationFromStorage[*Config](ctx, b, req.Storage, ConfigKey)
+	if err != nil {
+		// Names are still resolved, without metadata
+		return resolver
+	}
+	resolver.metadataKeys = config.EntityMetadataKeys
+	return resolver
+}
+
+// Resolve returns the entity of 'entityID', or nil if it cannot be resolved
+func (r entityResolver) Resolve(entityID string) *responses.EntityResponse {
+	if r.b == nil || entityID == "" {
+		return nil
+	}
+	entity := r.b.entityInfo(entityID)
+	if entity == nil {
+		return nil
+	}
+
+	entityResponse := &responses.EntityResponse{
+		ID:      entityID,
+		Name:    entity.Name,
+		Aliases: []responses.EntityAliasResponse{},
+	}
+	for _, alias := range entity.Aliases {
+		entityResponse.Aliases = append(entityResponse.Aliases, responses.EntityAliasResponse{
+			Name:      alias.Name,
+			MountType: alias.MountType,
+		})
+	}
+	for _, key := range r.metadataKeys {
+		value, ok := entity.Metadata[key]
+		if !ok {
+			continue
+		}
+		if entityResponse.Metadata == nil {
+			entityResponse.Metadata = map[string]string{}
+		}
+		entityResponse.Metadata[key] = value
+	}
+	return entityResponse
+}
@@ -0,0 +1,231 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+)
+
+/* ======================== Freeze
+A frozen Gate does not create, approve or claim AccessRequests,
+while the claims already active keep their access unless killed.
+*/
+
+/* Actions recorded on the Freeze */
+const FreezeActionFreeze = "freeze"
+const FreezeActionKill = "kill"
+const FreezeActionUnfreeze = "unfreeze"
+
+// Only the latest events are kept
+const FreezeEventsMax = 100
+
+// The message returned while frozen, if none is set
+const DefaultFreezeMessage = "The Gate is frozen"
+
+// FreezeEvent records who froze, killed or unfroze the Gate, and why
+type FreezeEvent struct {
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"iat"`
+	// The number of claims revoked (for 'kill')
+	Revoked int `json:"revoked,omitempty"`
+}
+
+// Freeze is the freeze state of the Gate
+type Freeze struct {
+	Frozen  bool   `json:"frozen"`
+	Message string `json:"message"`
+	// The latest events, oldest first
+	Events []FreezeEvent `json:"events"`
+}
+
+// KillReport is the outcome of killing the active claims
+type KillReport struct {
+	Revoked []string          `json:"revoked"`
+	Queued  []string          `json:"queued"`
+	Failed  map[string]string `json:"failed"`
+}
+
+// record appends an event, keeping the latest 'FreezeEventsMax'
+func (f *Freeze) record(event FreezeEvent) {
+	f.Events = append(f.Events, event)
+	if len(f.Events) > FreezeEventsMax {
+		f.Events = f.Events[len(f.Events)-FreezeEventsMax:]
+	}
+}
+
+func (b *BaseBackend) GetFreezeFromStorage(ctx context.Context, storage logical.Storage) (*Freeze, error) {
+	entry, err := storage.Get(ctx, FreezeKey)
+	if err != nil {
+		return nil, fmt.Errorf("Could not retrieve the freeze state")
+	}
+	if entry == nil {
+		return &Freeze{Events: []FreezeEvent{}}, nil
+	}
+
+	var freeze Freeze
+	if err := json.Unmarshal(entry.Value, &freeze); err != nil {
+		return nil, fmt.Errorf("The freeze state could not be retrieved")
+	}
+	return &freeze, nil
+}
+
+func (b *BaseBackend) storeFreezeToStorage(ctx context.Context, storage logical.Storage, freeze *Freeze) error {
+	freezeJSON, err := json.Marshal(freeze)
+	if err != nil {
+		return err
+	}
+	if err := storage.Put(ctx, &logical.StorageEntry{Key: FreezeKey, Value: freezeJSON}); err != nil {
+		return fmt.Errorf("Could not store the freeze state")
+	}
+	return nil
+}
+
+// updateFreeze applies 'update' to the stored freeze state
+func (b *BaseBackend) updateFreeze(ctx context.Context, storage logical.Storage, update func(*Freeze)) (*Freeze, error) {
+	b.freezeMutex.Lock()
+	defer b.freezeMutex.Unlock()
+
+	freeze, err := b.GetFreezeFromStorage(ctx, storage)
+	if err != nil {
+		return nil, err
+	}
+	update(freeze)
+	if err := b.storeFreezeToStorage(ctx, storage, freeze); err != nil {
+		return nil, err
+	}
+	return freeze, nil
+}
+
+// SetFrozen freezes or unfreezes the Gate, recording it as done by 'actor' for 'reason'.
+// 'message' is returned by the blocked endpoints while frozen.
+func (b *BaseBackend) SetFrozen(ctx context.Context, storage logical.Storage, frozen bool, actor string, reason string, message string) (*Freeze, error) {
+	action := FreezeActionUnfreeze
+	if frozen {
+		action = FreezeActionFreeze
+		if message == "" {
+			message = DefaultFreezeMessage
+		}
+	} else {
+		message = ""
+	}
+
+	freeze, err := b.updateFreeze(ctx, storage, func(freeze *Freeze) {
+		freeze.Frozen = frozen
+		freeze.Message = message
+		freeze.record(FreezeEvent{
+			Action:    action,
+			Actor:     actor,
+			Reason:    reason,
+			CreatedAt: time.Now(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.Logger().Warn("[!] Gate freeze state changed",
+		"Frozen", frozen,
+		"Actor", actor,
+		"Reason", reason,
+	)
+	return freeze, nil
+}
+
+// frozenResponse returns the response of an endpoint blocked while the Gate is frozen, or nil
+func (b *BaseBackend) frozenResponse(ctx context.Context, storage logical.Storage) (*logical.Response, error) {
+	freeze, err := b.GetFreezeFromStorage(ctx, storage)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	if !freeze.Frozen {
+		return nil, nil
+	}
+	return logical.ErrorResponse(freeze.Message), logical.ErrPermissionDenied
+}
+
+// KillClaims revokes every active claim (see 'revokeClaim').
+// The Gate must be frozen before, so no AccessRequest is claimed while they are revoked.
+// It acquires the lock of each AccessRequest, so it must not be called while holding any of them.
+func (b *BaseBackend) KillClaims(ctx context.Context, req *logical.Request, actor string, reason string) (*KillReport, error) {
+	report := &KillReport{
+		Revoked: []string{},
+		Queued:  []string{},
+		Failed:  map[string]string{},
+	}
+
+	// Claims in progress were 'approved' when listed
+	requestIDs := map[string]struct{}{}
+	for _, status := range []models.AccessRequestStatus{models.Active, models.Approved} {
+		ids, err := b.listRequestIDsByStatus(ctx, req.Storage, status)
+		if err != nil {
+			return nil, err
+		}
+		for _, requestID := range ids {
+			requestIDs[requestID] = struct{}{}
+		}
+	}
+
+	for requestID := range requestIDs {
+		queued, revoked, err := b.killClaim(ctx, req, requestID, actor, reason)
+		if err != nil {
+			report.Failed[requestID] = fmt.Sprint(err)
+			continue
+		}
+		if queued {
+			report.Queued = append(report.Queued, requestID)
+		} else if revoked {
+			report.Revoked = append(report.Revoked, requestID)
+		}
+	}
+
+	_, err := b.updateFreeze(ctx, req.Storage, func(freeze *Freeze) {
+		freeze.record(FreezeEvent{
+			Action:    FreezeActionKill,
+			Actor:     actor,
+			Reason:    reason,
+			CreatedAt: time.Now(),
+			Revoked:   len(report.Revoked) + len(report.Queued),
+		})
+	})
+	b.Logger().Warn("[!] Active claims killed",
+		"Actor", actor,
+		"Reason", reason,
+		"Revoked", len(report.Revoked),
+		"Queued", len(report.Queued),
+		"Failed", len(report.Failed),
+	)
+	return report, err
+}
+
+// killClaim revokes the claim of 'requestID' if it is active, returning whether its removal was queued or done
+func (b *BaseBackend) killClaim(ctx context.Context, req *logical.Request, requestID string, actor string, reason string) (bool, bool, error) {
+	lock := b.RequestLock(requestID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	accessRequest, err := b.GetRequestFromStorage(ctx, req.Storage, requestID)
+	if err != nil {
+		return false, false, err
+	}
+	if accessRequest == nil || accessRequest.Status != models.Active {
+		return false, false, nil
+	}
+	queued, err := b.revokeClaim(ctx, req, accessRequest, RevocationReasonKill, actor, reason)
+	return queued, err == nil && !queued, err
+}
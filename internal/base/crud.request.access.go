@@ -0,0 +1,343 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+)
+
+/* ======================== Access snapshots
+AccessRequests keep the access configuration of the Gate ('AccessConfigKey')
+from their creation, so that claims grant what the approvers approved.
+*/
+
+// accessSnapshot returns the current access configuration of the Gate,
+// or nil if the Gate does not set 'AccessConfigKey'. The caller must hold 'ConfigMutex'.
+func (b *BaseBackend) accessSnapshot(ctx context.Context, storage logical.Storage) (json.RawMessage, error) {
+	if b.AccessConfigKey == "" {
+		return nil, nil
+	}
+	section, ok := b.configSection(b.AccessConfigKey)
+	if !ok {
+		return nil, &UnknownConfigKeyError{Key: b.AccessConfigKey}
+	}
+	config, err := b.getConfigSectionFromStorage(ctx, storage, section)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(config)
+}
+
+// GetAccessConfiguration returns the access configuration snapshotted on an AccessRequest ('access'),
+// or the stored one under 'path' for AccessRequests created without a snapshot
+func GetAccessConfiguration[T PluginConfig](ctx context.Context, b *BaseBackend, storage logical.Storage, access json.RawMessage, path string) (T, error) {
+	if len(access) == 0 {
+		b.ConfigMutex.RLock()
+		defer b.ConfigMutex.RUnlock()
+		return GetConfigurationFromStorage[T](ctx, b, storage, path)
+	}
+
+	var config T
+	if err := json.Unmarshal(access, &config); err != nil {
+		var zero T
+		return zero, err
+	}
+	return config, nil
+}
+
+// sameAccess returns whether the snapshotted access configurations 'a' and 'b' are equal,
+// regardless of their JSON encoding
+func sameAccess(a json.RawMessage, b json.RawMessage) bool {
+	return reflect.DeepEqual(accessResponse(a), accessResponse(b))
+}
+
+// accessResponse returns the snapshotted access configuration, as reported on AccessRequests
+func accessResponse(access json.RawMessage) map[string]interface{} {
+	if len(access) == 0 {
+		return nil
+	}
+	var accessMap map[string]interface{}
+	if err := json.Unmarshal(access, &accessMap); err != nil {
+		return nil
+	}
+	return accessMap
+}
+
+// ResetApprovalsOnAccessChange returns the approved and pending AccessRequests that snapshotted
+// a different access configuration to 'pending' without approvals, with the current access configuration,
+// if 'reset_approvals_on_access_change' is set. It returns the number of reset AccessRequests.
+// It must be called without holding 'ConfigMutex', after the access configuration is stored.
+func (b *BaseBackend) ResetApprovalsOnAccessChange(ctx context.Context, storage logical.Storage) (int, error) {
+	if b.AccessConfigKey == "" {
+		return 0, nil
+	}
+
+	b.ConfigMutex.RLock()
+	config, err := GetConfigurationFromStorage[*Config](ctx, b, storage, ConfigKey)
+	var access json.RawMessage
+	if err == nil && config.ResetApprovalsOnAccessChange {
+		access, err = b.accessSnapshot(ctx, storage)
+	}
+	b.ConfigMutex.RUnlock()
+	if err != nil || !config.ResetApprovalsOnAccessChange {
+		return 0, err
+	}
+
+	reset := 0
+	var errs []error
+	for _, status := range []models.AccessRequestStatus{models.Pending, models.Approved} {
+		requestIDs, err := b.listRequestIDsByStatus(ctx, storage, status)
+		if err != nil {
+			return reset, err
+		}
+		for _, requestID := range requestIDs {
+			changed, err := b.resetRequestApprovals(ctx, storage, requestID, access)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if changed {
+				reset++
+			}
+		}
+	}
+	return reset, errors.Join(errs...)
+}
+
+func (b *BaseBackend) resetRequestApprovals(ctx context.Context, storage logical.Storage, requestID string, access json.RawMessage) (bool, error) {
+	lock := b.RequestLock(requestID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	accessRequest, err := b.GetRequestFromStorage(ctx, storage, requestID)
+	if err != nil || accessRequest == nil {
+		return false, err
+	}
+	if accessRequest.Status != models.Pending && accessRequest.Status != models.Approved {
+		return false, nil
+	}
+	if bytes.Equal(accessRequest.Access, access) {
+		return false, nil
+	}
+
+	accessRequest.Access = access
+	accessRequest.Approvals = map[string]*Approval{}
+	accessRequest.Status = models.Pending
+	refreshRequestStatus(accessRequest)
+
+	if err := b.StoreRequestToStorage(ctx, storage, accessRequest); err != nil {
+		return false, err
+	}
+	b.Logger().Warn("[+] AccessRequest approvals reset, as the access configuration changed",
+		"RequestorID", requestID,
+		"Status", accessRequest.Status,
+	)
+	return true, nil
+}
+
+// SyncActiveClaims applies 'on_config_change' to the active claims granted with a different access configuration,
+// re-granting their access with the current one ('regrant') or removing it ('revoke').
+// It returns the number of changed claims. It must be called without holding 'ConfigMutex'.
+func (b *BaseBackend) SyncActiveClaims(ctx context.Context, req *logical.Request) (int, error) {
+	if b.AccessConfigKey == "" {
+		return 0, nil
+	}
+
+	b.ConfigMutex.RLock()
+	config, err := GetConfigurationFromStorage[*Config](ctx, b, req.Storage, ConfigKey)
+	var access json.RawMessage
+	if err == nil && config.OnConfigChange != OnConfigChangeKeep {
+		access, err = b.accessSnapshot(ctx, req.Storage)
+	}
+	b.ConfigMutex.RUnlock()
+	if err != nil || config.OnConfigChange == OnConfigChangeKeep {
+		return 0, err
+	}
+
+	requestIDs, err := b.listRequestIDsByStatus(ctx, req.Storage, models.Active)
+	if err != nil {
+		return 0, err
+	}
+	synced := 0
+	var errs []error
+	for _, requestID := range requestIDs {
+		changed, err := b.syncActiveClaim(ctx, req, requestID, config.OnConfigChange, access)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if changed {
+			synced++
+		}
+	}
+	return synced, errors.Join(errs...)
+}
+
+func (b *BaseBackend) syncActiveClaim(ctx context.Context, req *logical.Request, requestID string, onConfigChange string, access json.RawMessage) (bool, error) {
+	lock := b.RequestLock(requestID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	accessRequest, err := b.GetRequestFromStorage(ctx, req.Storage, requestID)
+	if err != nil || accessRequest == nil {
+		return false, err
+	}
+	if accessRequest.Status != models.Active || bytes.Equal(accessRequest.Access, access) {
+		return false, nil
+	}
+	if accessRequest.ClaimData == nil {
+		b.Logger().Warn("[!] Active claim cannot be synced, as it was granted before claims kept their data",
+			"RequestorID", requestID,
+		)
+		return false, nil
+	}
+
+	if onConfigChange != OnConfigChangeRegrant {
+		accessRequest.RecordRevocation(RevocationReasonConfigChange, req.EntityID, "")
+		if err := b.StoreRequestToStorage(ctx, req.Storage, accessRequest); err != nil {
+			return false, err
+		}
+	}
+	// Only the access granted by the claim is removed from the current state (see 'CallbackArray.Remove'),
+	// so access assigned since the claim is kept, and the re-grant records it as already in place
+	if _, err := b.ClaimArray.Remove(ctx, req, requestID, accessRequest.ClaimData); err != nil {
+		if onConfigChange == OnConfigChangeRegrant {
+			return false, fmt.Errorf("could not remove the access of '%s': %w", requestID, err)
+		}
+		// The revocation is recorded, so the queue sets the AccessRequest as 'revoked' once it removes the access
+		if _, err2 := b.queueRevocation(ctx, req.Storage, requestID, accessRequest.ClaimData, err); err2 != nil {
+			return false, fmt.Errorf("could not remove the access of '%s': %w", requestID, err)
+		}
+		return true, nil
+	}
+
+	if onConfigChange == OnConfigChangeRegrant {
+		claimData, err := b.regrantClaim(ctx, req, accessRequest, access)
+		if err == nil {
+			return true, nil
+		}
+		if claimData != nil {
+			// The re-grant was not stored, and is left to the WAL rollback
+			return false, err
+		}
+		// The previous access is removed, so the claim is revoked
+		b.Logger().Error("[-] Could not re-grant active claim, revoking it",
+			"RequestorID", requestID,
+			"error", err,
+		)
+		accessRequest.RecordRevocation(RevocationReasonConfigChange, req.EntityID, "")
+	}
+
+	accessRequest.Revoke()
+	if err := b.StoreRequestToStorage(ctx, req.Storage, accessRequest); err != nil {
+		return false, err
+	}
+	b.Logger().Warn("[+] Active claim revoked, as the access configuration changed",
+		"RequestorID", requestID,
+	)
+	return true, nil
+}
+
+// regrantClaim grants 'access' to the active claim of 'accessRequest', whose previous access was removed,
+// and stores it. A failed grant is rolled back, keeping the access held outside of the claim.
+// If the grant succeeded but was not stored, its data is returned along with the error,
+// and the grant is rolled back by the WAL rollback (see 'rollbackRegrant').
+// The caller must hold the lock of the AccessRequest.
+func (b *BaseBackend) regrantClaim(ctx context.Context, req *logical.Request, accessRequest *AccessRequest, access json.RawMessage) (map[string]interface{}, error) {
+	requestID := accessRequest.OwnerID
+	previous, err := b.ClaimArray.Snapshot(ctx, req, requestID, access)
+	if err != nil {
+		return nil, err
+	}
+
+	// Access granted from now on is rolled back, unless the re-grant is stored
+	walID, err := b.putRegrantWAL(ctx, req, accessRequest, access, previous)
+	if err != nil {
+		return nil, err
+	}
+
+	claimData, err := b.ClaimArray.Append(ctx, req, requestID, access)
+	if err != nil {
+		// The access may be partially granted, so it is rolled back right away
+		if err2 := b.ClaimArray.Rollback(ctx, req, requestID, access, previous); err2 != nil {
+			b.Logger().Error("[-] Could not roll back the access of a failed re-grant, leaving it to the WAL rollback",
+				"RequestorID", requestID,
+				"error", err2,
+			)
+			return nil, err
+		}
+		if err2 := framework.DeleteWAL(ctx, req.Storage, walID); err2 != nil {
+			b.Logger().Warn("[!] Could not delete the WAL entry of a rolled back re-grant",
+				"RequestorID", requestID,
+				"error", err2,
+			)
+		}
+		return nil, err
+	}
+
+	claimData["requestor_id"] = requestID
+	claimData[ClaimIDKey] = accessRequest.ClaimID
+	claimData[ClaimCreatedAtKey] = accessRequest.ClaimCreatedAt.Format(time.RFC3339Nano)
+	regranted := *accessRequest
+	regranted.ClaimData = claimData
+	regranted.Access = access
+	if err := b.StoreRequestToStorage(ctx, req.Storage, &regranted); err != nil {
+		return claimData, err
+	}
+	*accessRequest = regranted
+
+	// The WAL rollback finds the re-grant stored if the entry remains
+	if err := framework.DeleteWAL(ctx, req.Storage, walID); err != nil {
+		b.Logger().Warn("[!] Could not delete the WAL entry of the re-grant",
+			"RequestorID", requestID,
+			"error", err,
+		)
+	}
+	b.Logger().Warn("[+] Active claim re-granted, as the access configuration changed",
+		"RequestorID", requestID,
+	)
+	return claimData, nil
+}
+
+// AccessChanged is called after the access configuration is stored, returning warnings
+func (b *BaseBackend) AccessChanged(ctx context.Context, req *logical.Request) []string {
+	warnings := []string{}
+
+	reset, err := b.ResetApprovalsOnAccessChange(ctx, req.Storage)
+	if reset > 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"%d AccessRequests returned to 'pending' without approvals, as the access configuration changed", reset,
+		))
+	}
+	if err != nil {
+		warnings = append(warnings, fmt.Sprint(err))
+	}
+
+	synced, err := b.SyncActiveClaims(ctx, req)
+	if synced > 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"%d active claims were synced with the access configuration ('on_config_change')", synced,
+		))
+	}
+	if err != nil {
+		warnings = append(warnings, fmt.Sprint(err))
+	}
+	return warnings
+}
@@ -43,5 +43,7 @@ func (b *BaseBackend) DeleteRequestFromStorage(ctx context.Context, storage logi
 		)
 		return err
 	}
-	return nil
+	// The given AccessRequest may carry a status computed in memory,
+	// so it is removed from all status indexes
+	return b.deleteAllStatusIndexes(ctx, storage, requestID)
 }
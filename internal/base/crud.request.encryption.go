@@ -0,0 +1,248 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+/*
+	When 'encrypt_requests' is set in '/config', AccessRequests are stored
+	as an encryptedRequest envelope, holding the AccessRequest JSON encrypted
+	with AES-256-GCM under a plugin-managed key. The RequestorID is used
+	as Additional Data, binding each ciphertext to its storage entry.
+
+	The keys are kept in a seal-wrapped RequestKeyring under 'RequestKeyringKey'.
+	Rotating adds a new key version; AccessRequests under older versions
+	are re-encrypted by the periodic tidy.
+*/
+
+type RequestEncryptionKey struct {
+	Key       []byte    `json:"key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RequestKeyring struct {
+	CurrentVersion int                           `json:"current_version"`
+	Keys           map[int]*RequestEncryptionKey `json:"keys"`
+}
+
+type encryptedRequest struct {
+	KeyVersion int    `json:"key_version"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func newRequestEncryptionKey() (*RequestEncryptionKey, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return &RequestEncryptionKey{
+		Key:       key,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (b *BaseBackend) GetRequestKeyringFromStorage(ctx context.Context, storage logical.Storage) (*RequestKeyring, error) {
+	entry, err := storage.Get(ctx, RequestKeyringKey)
+	if err != nil {
+		b.Logger().Error("[-] Could not retrieve request keyring from storage",
+			"error", err,
+		)
+		return nil, fmt.Errorf("Could not retrieve request keyring from BaseBackend")
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var keyring RequestKeyring
+	if err := json.Unmarshal(entry.Value, &keyring); err != nil {
+		b.Logger().Error("[-] Failed to unmarshal RequestKeyring",
+			"error", err,
+		)
+		return nil, fmt.Errorf("Request keyring could not be retrieved")
+	}
+	return &keyring, nil
+}
+
+func (b *BaseBackend) storeRequestKeyringToStorage(ctx context.Context, storage logical.Storage, keyring *RequestKeyring) error {
+	keyringJSON, err := json.Marshal(keyring)
+	if err != nil {
+		return err
+	}
+	return storage.Put(ctx, &logical.StorageEntry{
+		Key:      RequestKeyringKey,
+		Value:    keyringJSON,
+		SealWrap: true,
+	})
+}
+
+// EnsureRequestKeyring creates the RequestKeyring with its first key, if it does not exist
+func (b *BaseBackend) EnsureRequestKeyring(ctx context.Context, storage logical.Storage) error {
+	b.keyringMutex.Lock()
+	defer b.keyringMutex.Unlock()
+
+	keyring, err := b.GetRequestKeyringFromStorage(ctx, storage)
+	if err != nil || keyring != nil {
+		return err
+	}
+
+	key, err := newRequestEncryptionKey()
+	if err != nil {
+		return err
+	}
+	return b.storeRequestKeyringToStorage(ctx, storage, &RequestKeyring{
+		CurrentVersion: 1,
+		Keys:           map[int]*RequestEncryptionKey{1: key},
+	})
+}
+
+// RotateRequestKey adds a new key to the RequestKeyring, used for all following encryptions
+func (b *BaseBackend) RotateRequestKey(ctx context.Context, storage logical.Storage) (*RequestKeyring, error) {
+	b.keyringMutex.Lock()
+	defer b.keyringMutex.Unlock()
+
+	keyring, err := b.GetRequestKeyringFromStorage(ctx, storage)
+	if err != nil {
+		return nil, err
+	}
+	if keyring == nil {
+		keyring = &RequestKeyring{Keys: map[int]*RequestEncryptionKey{}}
+	}
+
+	key, err := newRequestEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	keyring.CurrentVersion++
+	keyring.Keys[keyring.CurrentVersion] = key
+
+	if err := b.storeRequestKeyringToStorage(ctx, storage, keyring); err != nil {
+		b.Logger().Error("[-] Could not store rotated request keyring",
+			"error", err,
+		)
+		return nil, err
+	}
+	return keyring, nil
+}
+
+func (b *BaseBackend) getRequestKeyring(ctx context.Context, storage logical.Storage) (*RequestKeyring, error) {
+	b.keyringMutex.RLock()
+	defer b.keyringMutex.RUnlock()
+
+	keyring, err := b.GetRequestKeyringFromStorage(ctx, storage)
+	if err != nil {
+		return nil, err
+	}
+	if keyring == nil {
+		return nil, fmt.Errorf("the request keyring is not initialized")
+	}
+	return keyring, nil
+}
+
+func newGCM(key *RequestEncryptionKey) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key.Key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encodeRequestEntry returns the stored form of the AccessRequest JSON,
+// encrypted if 'encrypt_requests' is configured.
+func (b *BaseBackend) encodeRequestEntry(ctx context.Context, storage logical.Storage, requestID string, requestJSON []byte) ([]byte, error) {
+	config, err := GetConfigurationFromStorage[*Config](ctx, b, storage, ConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if !config.EncryptRequests {
+		return requestJSON, nil
+	}
+
+	keyring, err := b.getRequestKeyring(ctx, storage)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(keyring.Keys[keyring.CurrentVersion])
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return json.Marshal(encryptedRequest{
+		KeyVersion: keyring.CurrentVersion,
+		Ciphertext: gcm.Seal(nonce, nonce, requestJSON, []byte(requestID)),
+	})
+}
+
+// decodeRequestEntry returns the AccessRequest JSON of a stored entry, decrypting it if needed,
+// along with the key version it was encrypted with (0 if it is not encrypted).
+func (b *BaseBackend) decodeRequestEntry(ctx context.Context, storage logical.Storage, requestID string, value []byte) ([]byte, int, error) {
+	var envelope encryptedRequest
+	if err := json.Unmarshal(value, &envelope); err != nil {
+		return nil, 0, err
+	}
+	if envelope.KeyVersion == 0 {
+		return value, 0, nil
+	}
+
+	keyring, err := b.getRequestKeyring(ctx, storage)
+	if err != nil {
+		return nil, 0, err
+	}
+	key, ok := keyring.Keys[envelope.KeyVersion]
+	if !ok {
+		return nil, 0, fmt.Errorf("the request key version %d does not exist", envelope.KeyVersion)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(envelope.Ciphertext) < gcm.NonceSize() {
+		return nil, 0, fmt.Errorf("the encrypted request is malformed")
+	}
+
+	nonce, ciphertext := envelope.Ciphertext[:gcm.NonceSize()], envelope.Ciphertext[gcm.NonceSize():]
+	requestJSON, err := gcm.Open(nil, nonce, ciphertext, []byte(requestID))
+	if err != nil {
+		return nil, 0, err
+	}
+	return requestJSON, envelope.KeyVersion, nil
+}
+
+// requestNeedsReencryption returns whether a stored entry does not match
+// the current encryption configuration and key version.
+func (b *BaseBackend) requestNeedsReencryption(ctx context.Context, storage logical.Storage, keyVersion int) (bool, error) {
+	config, err := GetConfigurationFromStorage[*Config](ctx, b, storage, ConfigKey)
+	if err != nil {
+		return false, err
+	}
+	if !config.EncryptRequests {
+		return keyVersion != 0, nil
+	}
+
+	keyring, err := b.getRequestKeyring(ctx, storage)
+	if err != nil {
+		return false, err
+	}
+	return keyVersion != keyring.CurrentVersion, nil
+}
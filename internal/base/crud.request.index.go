@@ -0,0 +1,133 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+)
+
+/*
+	The status index holds an empty entry under 'index/status/<status>/<requestor_id>'
+	for the stored status of each AccessRequest.
+
+	The index entry of the new status is written before the AccessRequest
+	and the old one is removed after it, so the index may point to
+	AccessRequests not in that status anymore (always re-checked when read),
+	but never misses one.
+*/
+
+// statusIndexSources maps a status to the stored statuses
+// an AccessRequest in that status can be indexed under,
+// as some transitions are only computed in memory (see 'refreshRequestStatus').
+var statusIndexSources = map[models.AccessRequestStatus][]models.AccessRequestStatus{
+	models.Pending:   {models.Pending},
+	models.Approved:  {models.Pending, models.Approved},
+	models.Active:    {models.Active},
+	models.Expired:   {models.Approved, models.Rejected, models.Expired},
+	models.Abandoned: {models.Pending, models.Abandoned},
+	models.Rejected:  {models.Rejected},
+	models.Revoked:   {models.Revoked},
+}
+
+func storageKeyForStatusIndex(status models.AccessRequestStatus, requestID string) string {
+	return fmt.Sprintf("%s/status/%s/%s", IndexKey, status, requestID)
+}
+
+func (b *BaseBackend) putStatusIndex(ctx context.Context, storage logical.Storage, status models.AccessRequestStatus, requestID string) error {
+	err := storage.Put(ctx, &logical.StorageEntry{
+		Key:   storageKeyForStatusIndex(status, requestID),
+		Value: []byte{},
+	})
+	if err != nil {
+		b.Logger().Error("[-] Could not store AccessRequest status index",
+			"RequestorID", requestID,
+			"Status", status,
+			"error", err,
+		)
+	}
+	return err
+}
+
+func (b *BaseBackend) deleteStatusIndex(ctx context.Context, storage logical.Storage, status models.AccessRequestStatus, requestID string) error {
+	err := storage.Delete(ctx, storageKeyForStatusIndex(status, requestID))
+	if err != nil {
+		b.Logger().Error("[-] Could not delete AccessRequest status index",
+			"RequestorID", requestID,
+			"Status", status,
+			"error", err,
+		)
+	}
+	return err
+}
+
+// deleteAllStatusIndexes removes the AccessRequest from the index of every status
+func (b *BaseBackend) deleteAllStatusIndexes(ctx context.Context, storage logical.Storage, requestID string) error {
+	for i := range models.AccessRequestStatusStrings {
+		err := b.deleteStatusIndex(ctx, storage, models.AccessRequestStatus(i), requestID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listRequestIDsByStatus returns the sorted IDs of AccessRequests that may be in 'status'
+func (b *BaseBackend) listRequestIDsByStatus(ctx context.Context, storage logical.Storage, status models.AccessRequestStatus) ([]string, error) {
+	requestIDs := map[string]struct{}{}
+	for _, sourceStatus := range statusIndexSources[status] {
+		entries, err := storage.List(ctx, storageKeyForStatusIndex(sourceStatus, ""))
+		if err != nil {
+			b.Logger().Error("[-] Could not list AccessRequest status index",
+				"Status", sourceStatus,
+				"error", err,
+			)
+			return nil, err
+		}
+		for _, requestID := range entries {
+			requestIDs[requestID] = struct{}{}
+		}
+	}
+
+	sorted := make([]string, 0, len(requestIDs))
+	for requestID := range requestIDs {
+		sorted = append(sorted, requestID)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+// indexRequests adds every stored AccessRequest to the index of its stored status
+func (b *BaseBackend) indexRequests(ctx context.Context, storage logical.Storage) error {
+	entries, err := storage.List(ctx, storageKeyForRequest(""))
+	if err != nil {
+		return fmt.Errorf("unable to list requests: %w", err)
+	}
+
+	for _, requestID := range entries {
+		lock := b.RequestLock(requestID)
+		lock.Lock()
+		accessRequest, err := b.readRequestFromStorage(ctx, storage, requestID)
+		if err == nil && accessRequest != nil {
+			err = b.putStatusIndex(ctx, storage, accessRequest.Status, requestID)
+		}
+		lock.Unlock()
+		if err != nil {
+			return fmt.Errorf("unable to index request '%s': %w", requestID, err)
+		}
+	}
+	return nil
+}
@@ -13,31 +13,92 @@ package base
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
 )
 
-func (b *BaseBackend) ListRequests(ctx context.Context, req *logical.Request) ([]AccessRequest, error) {
-	entityID := req.EntityID
+/* Sort orders of listed AccessRequests */
+const SortByRequestorID = "requestor_id"
+const SortByCreation = "iat"
 
-	accessRequests := []AccessRequest{}
+// RequestFilter selects, orders and paginates the AccessRequests returned by 'ListRequests'
+type RequestFilter struct {
+	Status        *models.AccessRequestStatus
+	RequestorID   string
+	ApprovedBy    string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
 
-	entries, err := req.Storage.List(ctx, storageKeyForRequest(""))
+	SortBy string
+	// After is the cursor of the last AccessRequest of the previous page (see 'requestCursor'):
+	// its RequestorID, or its creation time and RequestorID when sorting by creation
+	After string
+	// Limit of 0 returns all AccessRequests
+	Limit int
+}
+
+func (f RequestFilter) matches(accessRequest AccessRequest) bool {
+	if f.Status != nil && accessRequest.Status != *f.Status {
+		return false
+	}
+	if f.RequestorID != "" && accessRequest.OwnerID != f.RequestorID {
+		return false
+	}
+	if f.ApprovedBy != "" && !accessRequest.isApprovedBy(f.ApprovedBy) {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && !accessRequest.CreatedAt.After(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !accessRequest.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// ListRequests returns a page of the AccessRequests matching 'filter',
+// along with the cursor of the next page ("" if this is the last one).
+// It acquires the lock of each listed AccessRequest while reading it,
+// so it must not be called while holding any AccessRequest lock.
+func (b *BaseBackend) ListRequests(ctx context.Context, req *logical.Request, filter RequestFilter) ([]AccessRequest, string, error) {
+	entityID := req.EntityID
+
+	requestIDs, err := b.listCandidateRequestIDs(ctx, req.Storage, filter)
 	if err != nil {
 		b.Logger().Error("[-] Could not list request entries",
 			"EntityID", entityID,
 			"error", err,
 		)
-		return nil, fmt.Errorf("unable to list requests: %w", err)
+		return nil, "", fmt.Errorf("unable to list requests: %w", err)
+	}
+
+	// Request IDs are sorted, so when sorting by them
+	// the cursor and the limit apply before reading from storage
+	sortByID := filter.SortBy == "" || filter.SortBy == SortByRequestorID
+	if sortByID && filter.After != "" {
+		start := sort.SearchStrings(requestIDs, filter.After)
+		if start < len(requestIDs) && requestIDs[start] == filter.After {
+			start++
+		}
+		requestIDs = requestIDs[start:]
 	}
 
-	for _, requestID := range entries {
-		// b.Logger().Info("[*] Iterating key",
-		// 	"key", key,
-		// )
+	accessRequests := []AccessRequest{}
+	for _, requestID := range requestIDs {
+		if sortByID && filter.Limit > 0 && len(accessRequests) > filter.Limit {
+			break
+		}
 
-		// getRequest refreshes the AccessRequest state (Approvals, )
+		lock := b.RequestLock(requestID)
+		lock.RLock()
 		accessRequest, err := b.GetRequest(ctx, req, requestID)
+		lock.RUnlock()
 		if err != nil {
 			b.Logger().Error("[-] Could not retrieve AccessRequest",
 				"EntityID", entityID,
@@ -46,11 +107,84 @@ func (b *BaseBackend) ListRequests(ctx context.Context, req *logical.Request) ([
 			)
 			continue
 		}
-		// It is possible that the fetched AccessRequest has been deleted as EOL is passed.
+		// It is possible that the fetched AccessRequest is past its EOL (to be deleted).
 		// in that case 'getRequest' yields 'nil'
-		if accessRequest != nil {
+		if accessRequest != nil && filter.matches(*accessRequest) {
 			accessRequests = append(accessRequests, *accessRequest)
 		}
 	}
-	return accessRequests, nil
+
+	if !sortByID {
+		if filter.SortBy != SortByCreation {
+			return nil, "", fmt.Errorf("unknown sort order: %s", filter.SortBy)
+		}
+		sort.Slice(accessRequests, func(i, j int) bool {
+			return createdBefore(accessRequests[i].CreatedAt, accessRequests[i].OwnerID,
+				accessRequests[j].CreatedAt, accessRequests[j].OwnerID)
+		})
+		if filter.After != "" {
+			// The page resumes after the position of the cursor, even if its AccessRequest
+			// no longer matches the filter, was deleted or was re-created since
+			afterCreatedAt, afterID, err := parseCreationCursor(filter.After)
+			if err != nil {
+				return nil, "", err
+			}
+			start := sort.Search(len(accessRequests), func(i int) bool {
+				return createdBefore(afterCreatedAt, afterID, accessRequests[i].CreatedAt, accessRequests[i].OwnerID)
+			})
+			accessRequests = accessRequests[start:]
+		}
+	}
+
+	next := ""
+	if filter.Limit > 0 && len(accessRequests) > filter.Limit {
+		accessRequests = accessRequests[:filter.Limit]
+		next = requestCursor(accessRequests[filter.Limit-1], filter.SortBy)
+	}
+	return accessRequests, next, nil
+}
+
+// createdBefore orders AccessRequests by creation time, and by RequestorID if created at the same time
+func createdBefore(createdAt time.Time, requestID string, otherCreatedAt time.Time, otherRequestID string) bool {
+	if !createdAt.Equal(otherCreatedAt) {
+		return createdAt.Before(otherCreatedAt)
+	}
+	return requestID < otherRequestID
+}
+
+// requestCursor returns the cursor of 'accessRequest' when listed in 'sortBy' order:
+// its RequestorID, or '<creation Unix time in nanoseconds>:<RequestorID>' when sorting by creation
+func requestCursor(accessRequest AccessRequest, sortBy string) string {
+	if sortBy != SortByCreation {
+		return accessRequest.OwnerID
+	}
+	return fmt.Sprintf("%d:%s", accessRequest.CreatedAt.UnixNano(), accessRequest.OwnerID)
+}
+
+// parseCreationCursor returns the creation time and RequestorID of a cursor of the creation order
+func parseCreationCursor(cursor string) (time.Time, string, error) {
+	createdAtString, requestID, found := strings.Cut(cursor, ":")
+	createdAt, err := strconv.ParseInt(createdAtString, 10, 64)
+	if !found || err != nil {
+		return time.Time{}, "", fmt.Errorf("invalid 'after' cursor '%s' for the '%s' order", cursor, SortByCreation)
+	}
+	return time.Unix(0, createdAt), requestID, nil
+}
+
+// listCandidateRequestIDs returns the sorted IDs of the AccessRequests that may match 'filter',
+// using the status index instead of the whole 'request/' prefix where possible.
+func (b *BaseBackend) listCandidateRequestIDs(ctx context.Context, storage logical.Storage, filter RequestFilter) ([]string, error) {
+	if filter.RequestorID != "" {
+		return []string{filter.RequestorID}, nil
+	}
+	if filter.Status != nil {
+		return b.listRequestIDsByStatus(ctx, storage, *filter.Status)
+	}
+
+	requestIDs, err := storage.List(ctx, storageKeyForRequest(""))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(requestIDs)
+	return requestIDs, nil
 }
@@ -37,7 +37,35 @@ func (b *BaseBackend) GetRequest(ctx context.Context, req *logical.Request, requ
 	return accessRequest, nil
 }
 
+// GetRequestFromStorage returns the AccessRequest with its derived status
+// (abandoned, expired, approved) computed in memory. It never writes to storage,
+// so it is safe to use on performance standbys and secondaries.
+// AccessRequests past their deletion time are returned as 'nil'.
 func (b *BaseBackend) GetRequestFromStorage(ctx context.Context, storage logical.Storage, requestID string) (*AccessRequest, error) {
+	accessRequest, err := b.readRequestFromStorage(ctx, storage, requestID)
+	if err != nil || accessRequest == nil {
+		return nil, err
+	}
+
+	// Keep active grant state until lease revocation has completed. Once the
+	// request becomes terminal, normal deletion rules apply again.
+	if accessRequest.Status != models.Active && requestIsDeletable(*accessRequest) {
+		return nil, nil
+	}
+
+	refreshRequestStatus(accessRequest)
+	return accessRequest, nil
+}
+
+// readRequestFromStorage returns the AccessRequest exactly as stored.
+func (b *BaseBackend) readRequestFromStorage(ctx context.Context, storage logical.Storage, requestID string) (*AccessRequest, error) {
+	accessRequest, _, err := b.readRequestEntryFromStorage(ctx, storage, requestID)
+	return accessRequest, err
+}
+
+// readRequestEntryFromStorage returns the stored AccessRequest,
+// along with the key version it is encrypted with (0 if it is not encrypted).
+func (b *BaseBackend) readRequestEntryFromStorage(ctx context.Context, storage logical.Storage, requestID string) (*AccessRequest, int, error) {
 
 	entry, err := storage.Get(ctx, storageKeyForRequest(requestID))
 	if err != nil {
@@ -45,81 +73,33 @@ func (b *BaseBackend) GetRequestFromStorage(ctx context.Context, storage logical
 			"RequestorID", requestID,
 			"error", err,
 		)
-		return nil, fmt.Errorf("Could not retrieve request from BaseBackend")
+		return nil, 0, fmt.Errorf("Could not retrieve request from BaseBackend")
 	}
 	if entry == nil {
 		b.Logger().Warn("[!] Missing request entry in storage",
 			"RequestorID", requestID,
 		)
-		return nil, nil
+		return nil, 0, nil
 	}
 
-	var accessRequest AccessRequest
-	if err := json.Unmarshal(entry.Value, &accessRequest); err != nil {
-		b.Logger().Error("[-] Failed to unmarshal AccessRequest",
+	requestJSON, keyVersion, err := b.decodeRequestEntry(ctx, storage, requestID, entry.Value)
+	if err != nil {
+		b.Logger().Error("[-] Failed to decrypt AccessRequest",
 			"RequestorID", requestID,
 			"error", err,
 		)
-		return nil, fmt.Errorf("Request could not be retrieved")
+		return nil, 0, fmt.Errorf("Request could not be retrieved")
 	}
 
-	requestDirty := false
-	// Active access is governed by its Vault/OpenBao lease. Request expiry must
-	// not change active state before the lease revocation callback removes access.
-	if (accessRequest.Status != models.Active &&
-		accessRequest.Status != models.Expired &&
-		accessRequest.Status != models.Revoked &&
-		accessRequest.Status != models.Abandoned) && requestHasExpired(accessRequest) {
-		if accessRequest.Status == models.Pending {
-			accessRequest.Status = models.Abandoned
-		} else {
-			accessRequest.Status = models.Expired
-		}
-		requestDirty = true
-		b.Logger().Info("[*] Request status set",
-			"RequestorID", requestID,
-			"Status", accessRequest.Status,
-			"Expiration", accessRequest.Expiration,
-		)
-	}
-
-	// Keep active grant state until lease revocation has completed. Once the
-	// request becomes terminal, normal deletion rules apply again.
-	if accessRequest.Status != models.Active && requestIsDeletable(accessRequest) {
-		err := b.DeleteRequestFromStorage(ctx, storage, accessRequest)
-		if err != nil {
-			b.Logger().Error("[-] Failed to Deleted AccessRequest in Storage",
-				"RequestorID", requestID,
-				"Expiration", accessRequest.Expiration,
-				"DeleteAfter", accessRequest.Deletion,
-				"error", err,
-			)
-			return nil, err
-		}
-		b.Logger().Info("[*] Deleted AccessRequest after EOL",
+	var accessRequest AccessRequest
+	if err := json.Unmarshal(requestJSON, &accessRequest); err != nil {
+		b.Logger().Error("[-] Failed to unmarshal AccessRequest",
 			"RequestorID", requestID,
-			"Expiration", accessRequest.Expiration,
-			"DeleteAfter", accessRequest.Deletion,
+			"error", err,
 		)
-		// Return nothing as the AccessRequest was to be deleted
-		return nil, nil
-	}
-
-	// Handle Approval Status
-	if accessRequest.Status == models.Pending &&
-		requestIsApproved(accessRequest) {
-		accessRequest.Status = models.Approved
-		requestDirty = true
-	}
-	if requestDirty {
-		err := b.StoreRequestToStorage(ctx, storage, &accessRequest)
-		if err != nil {
-			b.Logger().Error("[-] Could not store changed AccessRequest",
-				"RequestorID", requestID,
-				"error", err,
-			)
-			return nil, err
-		}
+		return nil, 0, fmt.Errorf("Request could not be retrieved")
 	}
-	return &accessRequest, nil
+	// Entries not yet migrated are upgraded in memory
+	upgradeAccessRequest(&accessRequest)
+	return &accessRequest, keyVersion, nil
 }
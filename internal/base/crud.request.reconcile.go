@@ -0,0 +1,241 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/internal/utils"
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+)
+
+/* ======================== Reconciliation
+Claims are compared with the access actually in place (through 'ClaimArray.Verify'),
+as it can be changed outside of the plugin, or left in place by a failed revocation.
+*/
+
+// The last ReconcileReport is stored under this key
+const ReconcileKey = "reconcile"
+
+// Active claims are overdue if their lease was not revoked this long after their TTL
+const ReconcileGracePeriod = 5 * time.Minute
+
+/* Actions taken on discrepancies */
+const ReconcileReported = "reported"
+const ReconcileRemoved = "removed"
+const ReconcileExpired = "expired"
+const ReconcileRemoveFailed = "remove_failed"
+
+// ReconcileDiscrepancy is a claim whose access is not as expected
+type ReconcileDiscrepancy struct {
+	RequestorID string                     `json:"requestor_id"`
+	Status      models.AccessRequestStatus `json:"status"`
+	// The state of the access found ('absent', 'partial', 'present')
+	Grant  string `json:"grant"`
+	Action string `json:"action"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ReconcileReport is the outcome of a reconciliation
+type ReconcileReport struct {
+	RunAt         time.Time              `json:"run_at"`
+	Checked       int                    `json:"checked"`
+	Discrepancies []ReconcileDiscrepancy `json:"discrepancies"`
+}
+
+func (b *BaseBackend) GetReconcileReportFromStorage(ctx context.Context, storage logical.Storage) (*ReconcileReport, error) {
+	entry, err := storage.Get(ctx, ReconcileKey)
+	if err != nil {
+		return nil, fmt.Errorf("Could not retrieve the reconciliation report")
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var report ReconcileReport
+	if err := json.Unmarshal(entry.Value, &report); err != nil {
+		return nil, fmt.Errorf("The reconciliation report could not be retrieved")
+	}
+	return &report, nil
+}
+
+// Reconcile compares the claims of active and revoked/expired AccessRequests with the access in place.
+// Revoked/expired AccessRequests are checked until their access is found removed once (see 'Reconciled').
+// Access left in place after revocation and overdue active claims are removed,
+// while active claims missing access are reported. The report is stored under 'ReconcileKey'.
+// It writes to storage, so it must only run where storage is writable.
+func (b *BaseBackend) Reconcile(ctx context.Context, req *logical.Request) (*ReconcileReport, error) {
+	b.reconcileMutex.Lock()
+	defer b.reconcileMutex.Unlock()
+
+	report := &ReconcileReport{
+		RunAt:         time.Now(),
+		Discrepancies: []ReconcileDiscrepancy{},
+	}
+	for _, status := range []models.AccessRequestStatus{models.Active, models.Revoked, models.Expired} {
+		requestIDs, err := b.listRequestIDsByStatus(ctx, req.Storage, status)
+		if err != nil {
+			return nil, err
+		}
+		for _, requestID := range requestIDs {
+			checked, discrepancy := b.reconcileRequest(ctx, req, requestID)
+			if checked {
+				report.Checked++
+			}
+			if discrepancy != nil {
+				report.Discrepancies = append(report.Discrepancies, *discrepancy)
+			}
+		}
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, &logical.StorageEntry{Key: ReconcileKey, Value: reportJSON}); err != nil {
+		return nil, fmt.Errorf("Could not store the reconciliation report")
+	}
+
+	b.Logger().Info("[+] Claims reconciled with the granted access",
+		"Checked", report.Checked,
+		"Discrepancies", len(report.Discrepancies),
+	)
+	return report, nil
+}
+
+// reconcileRequest returns whether the claim of the AccessRequest was checked, and its discrepancy (if any)
+func (b *BaseBackend) reconcileRequest(ctx context.Context, req *logical.Request, requestID string) (bool, *ReconcileDiscrepancy) {
+	lock := b.RequestLock(requestID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	accessRequest, err := b.GetRequestFromStorage(ctx, req.Storage, requestID)
+	if err != nil || accessRequest == nil || accessRequest.ClaimData == nil {
+		// AccessRequests claimed before claims kept their data cannot be verified
+		return false, nil
+	}
+	if accessRequest.Status != models.Active && accessRequest.Reconciled {
+		// Access assigned since the claim was checked may overlap it, and must not be removed again
+		return false, nil
+	}
+	discrepancy := &ReconcileDiscrepancy{
+		RequestorID: requestID,
+		Status:      accessRequest.Status,
+		Action:      ReconcileReported,
+	}
+
+	if accessRequest.Status == models.Active &&
+		accessRequest.ClaimCreatedAt.Add(accessRequest.ClaimTTL+ReconcileGracePeriod).Before(time.Now()) {
+		// The lease was not revoked, so its access is removed here
+		discrepancy.Grant = utils.GrantPresent.String()
+		if _, err := b.ClaimArray.Remove(ctx, req, requestID, accessRequest.ClaimData); err != nil {
+			discrepancy.Action = ReconcileRemoveFailed
+			discrepancy.Error = fmt.Sprint(err)
+			return true, discrepancy
+		}
+		accessRequest.Revoke()
+		accessRequest.Reconciled = true
+		if err := b.StoreRequestToStorage(ctx, req.Storage, accessRequest); err != nil {
+			discrepancy.Error = fmt.Sprint(err)
+		}
+		discrepancy.Action = ReconcileExpired
+		b.Logger().Warn("[+] Overdue claim removed by reconciliation",
+			"RequestorID", requestID,
+			"ClaimTime", accessRequest.ClaimCreatedAt,
+			"ClaimTTL", accessRequest.ClaimTTL,
+		)
+		return true, discrepancy
+	}
+
+	grant, err := b.ClaimArray.Verify(ctx, req, requestID, accessRequest.ClaimData)
+	if err != nil {
+		discrepancy.Grant = "unknown"
+		discrepancy.Error = fmt.Sprint(err)
+		return true, discrepancy
+	}
+	discrepancy.Grant = grant.String()
+
+	if accessRequest.Status == models.Active {
+		if grant == utils.GrantPresent {
+			return true, nil
+		}
+		b.Logger().Warn("[!] Active claim is missing its access",
+			"RequestorID", requestID,
+			"Grant", grant,
+		)
+		return true, discrepancy
+	}
+
+	if grant == utils.GrantAbsent {
+		b.setReconciled(ctx, req, accessRequest)
+		return true, nil
+	}
+	if _, err := b.ClaimArray.Remove(ctx, req, requestID, accessRequest.ClaimData); err != nil {
+		// Checked again on the next reconciliation
+		discrepancy.Action = ReconcileRemoveFailed
+		discrepancy.Error = fmt.Sprint(err)
+		return true, discrepancy
+	}
+	b.setReconciled(ctx, req, accessRequest)
+	// Remove only takes away the access granted by the claim,
+	// so access it leaves in place is held outside of it (e.g.: assigned to the entity since)
+	if after, err := b.ClaimArray.Verify(ctx, req, requestID, accessRequest.ClaimData); err == nil && after == grant {
+		return true, nil
+	}
+	discrepancy.Action = ReconcileRemoved
+	b.Logger().Warn("[+] Access left in place after revocation removed by reconciliation",
+		"RequestorID", requestID,
+		"Status", accessRequest.Status,
+		"Grant", grant,
+	)
+	return true, discrepancy
+}
+
+// setReconciled records that the access of the terminated claim of 'accessRequest' was checked.
+// The caller must hold the lock of the AccessRequest.
+func (b *BaseBackend) setReconciled(ctx context.Context, req *logical.Request, accessRequest *AccessRequest) {
+	accessRequest.Reconciled = true
+	if err := b.StoreRequestToStorage(ctx, req.Storage, accessRequest); err != nil {
+		// Checked again on the next reconciliation
+		b.Logger().Warn("[!] Could not record the reconciliation of AccessRequest",
+			"RequestorID", accessRequest.OwnerID,
+			"error", err,
+		)
+	}
+}
+
+// reconcileIfDue runs 'Reconcile' if 'reconcile_interval' passed since the last reconciliation
+func (b *BaseBackend) reconcileIfDue(ctx context.Context, req *logical.Request) error {
+	b.ConfigMutex.RLock()
+	config, err := GetConfigurationFromStorage[*Config](ctx, b, req.Storage, ConfigKey)
+	b.ConfigMutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	if config.ReconcileInterval == 0 {
+		return nil
+	}
+
+	report, err := b.GetReconcileReportFromStorage(ctx, req.Storage)
+	if err != nil {
+		return err
+	}
+	if report != nil && report.RunAt.Add(config.ReconcileInterval).After(time.Now()) {
+		return nil
+	}
+	_, err = b.Reconcile(ctx, req)
+	return err
+}
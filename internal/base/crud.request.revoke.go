@@ -0,0 +1,76 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+)
+
+// revokeClaim revokes the active claim of 'accessRequest' through the Gate:
+// the revocation is recorded, the access is removed and the AccessRequest is set as 'revoked'.
+// Its lease is left to expire, finding its access already removed,
+// as lease IDs are passed to plugins only when the lease is revoked, and cannot be revoked through the system view.
+// If the access cannot be removed, its removal is queued (see 'queueRevocation') and true is returned.
+// The caller must hold the lock of the AccessRequest.
+func (b *BaseBackend) revokeClaim(ctx context.Context, req *logical.Request, accessRequest *AccessRequest, reason string, actor string, comment string) (bool, error) {
+	if accessRequest.Status != models.Active {
+		return false, fmt.Errorf(
+			"Cannot revoke an AccessRequest that is not in 'active' state (state: %s)",
+			accessRequest.Status,
+		)
+	}
+	if accessRequest.ClaimData == nil {
+		return false, fmt.Errorf("The claim cannot be revoked, as it was granted before claims kept their data")
+	}
+
+	accessRequest.RecordRevocation(reason, actor, comment)
+	if err := b.StoreRequestToStorage(ctx, req.Storage, accessRequest); err != nil {
+		return false, err
+	}
+	b.Logger().Warn("[*] Revoking claim",
+		"RequestorID", accessRequest.OwnerID,
+		"ClaimID", accessRequest.ClaimID,
+		"Reason", reason,
+		"RevokedBy", actor,
+	)
+
+	if _, err := b.ClaimArray.Remove(ctx, req, accessRequest.OwnerID, accessRequest.ClaimData); err != nil {
+		_, err2 := b.queueRevocation(ctx, req.Storage, accessRequest.OwnerID, accessRequest.ClaimData, err)
+		if err2 != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	accessRequest.Revoke()
+	if err := b.StoreRequestToStorage(ctx, req.Storage, accessRequest); err != nil {
+		return false, err
+	}
+	b.Logger().Warn("[+] Claim revoked",
+		"RequestorID", accessRequest.OwnerID,
+		"ClaimID", accessRequest.ClaimID,
+		"Reason", reason,
+	)
+	return false, nil
+}
+
+// revocationActor returns who revokes through 'req' (its Entity, or its token's display name)
+func revocationActor(req *logical.Request) string {
+	if req.EntityID != "" {
+		return req.EntityID
+	}
+	return req.DisplayName
+}
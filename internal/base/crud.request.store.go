@@ -13,11 +13,18 @@ package base
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
 )
 
+// ErrRequestVersionConflict is returned when an AccessRequest was changed
+// in storage after it was read by the caller.
+var ErrRequestVersionConflict = errors.New("AccessRequest was modified concurrently")
+
 func (b *BaseBackend) StoreRequest(ctx context.Context, req *logical.Request, accessRequest *AccessRequest) error {
 	entityID := req.EntityID
 
@@ -28,34 +35,126 @@ func (b *BaseBackend) StoreRequest(ctx context.Context, req *logical.Request, ac
 			"RequestorID", accessRequest.OwnerID,
 			"error", err,
 		)
+		if errors.Is(err, ErrRequestVersionConflict) {
+			return err
+		}
 		return fmt.Errorf("Could not store Access Request to Backend")
 	}
 	return nil
 }
 
+// StoreRequestToStorage persists the AccessRequest only if the stored version
+// matches 'accessRequest.Version' (Compare-And-Swap), and increments it on success.
+// A new AccessRequest replacing an existing one must carry the existing version.
 func (b *BaseBackend) StoreRequestToStorage(ctx context.Context, storage logical.Storage, accessRequest *AccessRequest) error {
+	requestID := accessRequest.OwnerID
+
+	stored, err := b.getStoredRequestState(ctx, storage, requestID)
+	if err != nil {
+		return err
+	}
+	if stored.Version != accessRequest.Version {
+		b.Logger().Warn("[!] AccessRequest version conflict",
+			"RequestorID", requestID,
+			"StoredVersion", stored.Version,
+			"Version", accessRequest.Version,
+		)
+		return ErrRequestVersionConflict
+	}
+
+	statusChanged := !stored.Exists || stored.Status != accessRequest.Status
+	if statusChanged {
+		if err := b.putStatusIndex(ctx, storage, accessRequest.Status, requestID); err != nil {
+			return err
+		}
+	}
 
+	accessRequest.Version++
 	requestJSON, err := json.Marshal(*accessRequest)
 	if err != nil {
+		accessRequest.Version--
 		b.Logger().Error("[-] Could not marshal AccessRequest to JSON",
-			"AccessRequest", accessRequest,
+			"RequestorID", accessRequest.OwnerID,
+			"Status", accessRequest.Status,
+			"error", err,
+		)
+		return err
+	}
+
+	requestEntry, err := b.encodeRequestEntry(ctx, storage, requestID, requestJSON)
+	if err != nil {
+		accessRequest.Version--
+		b.Logger().Error("[-] Could not encrypt AccessRequest",
+			"RequestorID", requestID,
 			"error", err,
 		)
 		return err
 	}
 
-	requestID := accessRequest.OwnerID
 	err = storage.Put(ctx, &logical.StorageEntry{
 		Key:   storageKeyForRequest(requestID),
-		Value: requestJSON,
+		Value: requestEntry,
 	})
 	if err != nil {
+		accessRequest.Version--
 		b.Logger().Error("[-] Could not store AccessRequest",
 			"RequestorID", requestID,
-			"RequestJSON", requestJSON,
 			"error", err,
 		)
 		return err
 	}
+
+	if statusChanged && stored.Exists {
+		// A leftover index entry is filtered out when read, so do not fail the write
+		_ = b.deleteStatusIndex(ctx, storage, stored.Status, requestID)
+	}
 	return nil
 }
+
+// storedRequestState holds the fields of a stored AccessRequest needed to replace it
+type storedRequestState struct {
+	Exists  bool                       `json:"-"`
+	Version uint64                     `json:"version"`
+	Status  models.AccessRequestStatus `json:"status"`
+}
+
+func (b *BaseBackend) getStoredRequestState(ctx context.Context, storage logical.Storage, requestID string) (storedRequestState, error) {
+	var stored storedRequestState
+
+	entry, err := storage.Get(ctx, storageKeyForRequest(requestID))
+	if err != nil {
+		b.Logger().Error("[-] Could not retrieve request from storage",
+			"RequestorID", requestID,
+			"error", err,
+		)
+		return stored, err
+	}
+	if entry == nil {
+		return stored, nil
+	}
+
+	requestJSON, _, err := b.decodeRequestEntry(ctx, storage, requestID, entry.Value)
+	if err != nil {
+		b.Logger().Error("[-] Failed to decrypt AccessRequest",
+			"RequestorID", requestID,
+			"error", err,
+		)
+		return stored, err
+	}
+	if err := json.Unmarshal(requestJSON, &stored); err != nil {
+		b.Logger().Error("[-] Failed to unmarshal AccessRequest",
+			"RequestorID", requestID,
+			"error", err,
+		)
+		return stored, err
+	}
+	stored.Exists = true
+	return stored, nil
+}
+
+// getRequestVersionFromStorage returns the version of the stored AccessRequest,
+// or 0 if it does not exist.
+func (b *BaseBackend) getRequestVersionFromStorage(ctx context.Context, storage logical.Storage, requestID string) (uint64, error) {
+	stored, err := b.getStoredRequestState(ctx, storage, requestID)
+	return stored.Version, err
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+)
+
+// TidyRequests persists the derived status of every AccessRequest,
+// re-encrypts the ones not under the current key and deletes the ones past their deletion time.
+// It writes to storage, so it must only run where storage is writable.
+func (b *BaseBackend) TidyRequests(ctx context.Context, storage logical.Storage) error {
+	entries, err := storage.List(ctx, storageKeyForRequest(""))
+	if err != nil {
+		b.Logger().Error("[-] Could not list request entries",
+			"error", err,
+		)
+		return fmt.Errorf("unable to list requests: %w", err)
+	}
+
+	var errs []error
+	for _, requestID := range entries {
+		if err := b.tidyRequest(ctx, storage, requestID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *BaseBackend) tidyRequest(ctx context.Context, storage logical.Storage, requestID string) error {
+	lock := b.RequestLock(requestID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	accessRequest, keyVersion, err := b.readRequestEntryFromStorage(ctx, storage, requestID)
+	if err != nil || accessRequest == nil {
+		return err
+	}
+
+	// Keep active grant state until lease revocation has completed. Once the
+	// request becomes terminal, normal deletion rules apply again.
+	if accessRequest.Status != models.Active && requestIsDeletable(*accessRequest) {
+		err := b.DeleteRequestFromStorage(ctx, storage, *accessRequest)
+		if err != nil {
+			b.Logger().Error("[-] Failed to Deleted AccessRequest in Storage",
+				"RequestorID", requestID,
+				"Expiration", accessRequest.Expiration,
+				"DeleteAfter", accessRequest.Deletion,
+				"error", err,
+			)
+			return err
+		}
+		b.Logger().Info("[*] Deleted AccessRequest after EOL",
+			"RequestorID", requestID,
+			"Expiration", accessRequest.Expiration,
+			"DeleteAfter", accessRequest.Deletion,
+		)
+		return nil
+	}
+
+	// Re-encrypt with the current key (or decrypt) if the encryption configuration changed
+	reencrypt, err := b.requestNeedsReencryption(ctx, storage, keyVersion)
+	if err != nil {
+		return err
+	}
+
+	statusChanged := refreshRequestStatus(accessRequest)
+	if !statusChanged && !reencrypt {
+		return nil
+	}
+	b.Logger().Info("[*] Request status set",
+		"RequestorID", requestID,
+		"Status", accessRequest.Status,
+		"Expiration", accessRequest.Expiration,
+		"Reencrypted", reencrypt,
+	)
+	if err := b.StoreRequestToStorage(ctx, storage, accessRequest); err != nil {
+		b.Logger().Error("[-] Could not store changed AccessRequest",
+			"RequestorID", requestID,
+			"error", err,
+		)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+)
+
+/* ======================== Claim Write-Ahead Log
+A WAL entry is written before 'ClaimArray.Append' grants access and deleted once the
+claim is stored, right before the lease is returned. Entries left behind belong to claims
+that never got a lease, and are rolled back by Vault/OpenBao's rollback manager.
+Entries record the access held before the claim ('ClaimArray.Snapshot'), which rollbacks keep.
+Re-grants of active claims ('on_config_change') write entries too, deleted once the re-grant is stored.
+*/
+
+// Kind of the WAL entries written by claims
+const ClaimWALKind = "claim"
+
+// Key of the claim data holding the ID of the claim, which ties it to its WAL entry
+const ClaimIDKey = "claim_id"
+
+// ClaimWAL is the data of a claim's WAL entry
+type ClaimWAL struct {
+	RequestorID string          `json:"requestor_id"`
+	ClaimID     string          `json:"claim_id"`
+	Access      json.RawMessage `json:"access,omitempty"`
+	// The access held before the claim (see 'CallbackArray.Snapshot'), kept on rollback
+	Previous map[string]interface{} `json:"previous,omitempty"`
+	// Set on entries of re-grants of the active claim 'ClaimID', which already has a lease
+	Regrant bool `json:"regrant,omitempty"`
+}
+
+// putClaimWAL writes the WAL entry of a claim of 'accessRequest', with the access held before it ('previous'),
+// returning its ID and the ID of the claim
+func (b *BaseBackend) putClaimWAL(ctx context.Context, req *logical.Request, accessRequest *AccessRequest, previous map[string]interface{}) (string, string, error) {
+	claimID, err := uuid.GenerateUUID()
+	if err != nil {
+		return "", "", err
+	}
+	walID, err := framework.PutWAL(ctx, req.Storage, ClaimWALKind, &ClaimWAL{
+		RequestorID: accessRequest.OwnerID,
+		ClaimID:     claimID,
+		Access:      accessRequest.Access,
+		Previous:    previous,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("Could not write the WAL entry of the claim")
+	}
+	return walID, claimID, nil
+}
+
+// putRegrantWAL writes the WAL entry of the re-grant of the active claim of 'accessRequest' with 'access',
+// with the access held before it ('previous'), returning its ID
+func (b *BaseBackend) putRegrantWAL(ctx context.Context, req *logical.Request, accessRequest *AccessRequest, access json.RawMessage, previous map[string]interface{}) (string, error) {
+	walID, err := framework.PutWAL(ctx, req.Storage, ClaimWALKind, &ClaimWAL{
+		RequestorID: accessRequest.OwnerID,
+		ClaimID:     accessRequest.ClaimID,
+		Access:      access,
+		Previous:    previous,
+		Regrant:     true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("Could not write the WAL entry of the re-grant")
+	}
+	return walID, nil
+}
+
+// WALRollback rolls back the WAL entries left by incomplete operations.
+// It is set as the 'WALRollback' function of the plugins' backends.
+func (b *BaseBackend) WALRollback(ctx context.Context, req *logical.Request, kind string, data interface{}) error {
+	switch kind {
+	case ClaimWALKind:
+		return b.rollbackClaim(ctx, req, data)
+	}
+	return fmt.Errorf("Unknown WAL entry kind '%s'", kind)
+}
+
+// rollbackClaim removes the access granted by a claim that never got a lease.
+func (b *BaseBackend) rollbackClaim(ctx context.Context, req *logical.Request, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var entry ClaimWAL
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return err
+	}
+	if entry.RequestorID == "" {
+		return fmt.Errorf("Claim WAL entry has no requestor_id")
+	}
+
+	lock := b.RequestLock(entry.RequestorID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	accessRequest, err := b.GetRequest(ctx, req, entry.RequestorID)
+	if err != nil {
+		return err
+	}
+	if entry.Regrant {
+		return b.rollbackRegrant(ctx, req, accessRequest, &entry)
+	}
+
+	if accessRequest == nil || accessRequest.Status != models.Active {
+		// The claim was not stored, so only the access it was granting is known
+		b.Logger().Warn("[!] Rolling back the access of an incomplete claim",
+			"RequestorID", entry.RequestorID,
+			"ClaimID", entry.ClaimID,
+		)
+		return b.ClaimArray.Rollback(ctx, req, entry.RequestorID, entry.Access, entry.Previous)
+	}
+
+	if accessRequest.ClaimID != entry.ClaimID {
+		// The access is now held by a later claim, which has a lease
+		b.Logger().Info("[*] AccessRequest was claimed again after the incomplete claim",
+			"RequestorID", entry.RequestorID,
+			"ClaimID", entry.ClaimID,
+		)
+		return nil
+	}
+
+	// The claim was stored, but its lease was never returned
+	b.Logger().Warn("[!] Rolling back a claim that has no lease",
+		"RequestorID", entry.RequestorID,
+		"ClaimID", entry.ClaimID,
+	)
+	removed, err := b.ClaimArray.Remove(ctx, req, entry.RequestorID, accessRequest.ClaimData)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("Claimed access was not removed")
+	}
+
+	// The AccessRequest can be claimed again
+	accessRequest.Status = models.Approved
+	accessRequest.ClaimCreatedAt = time.Unix(0, 0)
+	accessRequest.ClaimData = nil
+	accessRequest.ClaimID = ""
+	return b.StoreRequest(ctx, req, accessRequest)
+}
+
+// rollbackRegrant removes the access granted by a re-grant of an active claim that was not stored.
+// The access of the claim was removed before the re-grant, so the claim is revoked.
+func (b *BaseBackend) rollbackRegrant(ctx context.Context, req *logical.Request, accessRequest *AccessRequest, entry *ClaimWAL) error {
+	sameClaim := accessRequest != nil &&
+		accessRequest.Status == models.Active &&
+		accessRequest.ClaimID == entry.ClaimID
+	if sameClaim && sameAccess(accessRequest.Access, entry.Access) {
+		// The re-grant was stored, so the lease of the claim removes its access
+		return nil
+	}
+	if accessRequest != nil && accessRequest.Status == models.Active && !sameClaim {
+		// The access is now held by a later claim, which has a lease
+		b.Logger().Info("[*] AccessRequest was claimed again after the incomplete re-grant",
+			"RequestorID", entry.RequestorID,
+			"ClaimID", entry.ClaimID,
+		)
+		return nil
+	}
+
+	b.Logger().Warn("[!] Rolling back the access of an incomplete re-grant",
+		"RequestorID", entry.RequestorID,
+		"ClaimID", entry.ClaimID,
+	)
+	if err := b.ClaimArray.Rollback(ctx, req, entry.RequestorID, entry.Access, entry.Previous); err != nil {
+		return err
+	}
+	if !sameClaim {
+		return nil
+	}
+	accessRequest.RecordRevocation(RevocationReasonConfigChange, "", "")
+	accessRequest.Revoke()
+	return b.StoreRequest(ctx, req, accessRequest)
+}
@@ -0,0 +1,248 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+)
+
+/* ======================== Revocation Queue
+Claims whose access could not be removed when their lease was revoked, or when they were revoked
+through the Gate (e.g.: '/revoke', 'on_config_change'), are queued in storage.
+Their AccessRequest stays 'active' until the queue removes their access, retrying with exponential backoff.
+The status the AccessRequest gets then follows the revocation recorded on it (see 'AccessRequest.Revoke').
+*/
+
+/* Backoff of revocation retries */
+const RevocationRetryMinBackoff = 1 * time.Minute
+const RevocationRetryMaxBackoff = 1 * time.Hour
+
+// PendingRevocation is a claim whose access is still to be removed
+type PendingRevocation struct {
+	ID          string `json:"id"`
+	RequestorID string `json:"requestor_id"`
+	// The InternalData of the revoked lease
+	LeaseData map[string]interface{} `json:"lease_data"`
+	CreatedAt time.Time              `json:"iat"`
+
+	Attempts    int       `json:"attempts"`
+	LastAttempt time.Time `json:"last_attempt"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error"`
+}
+
+// revocationBackoff returns the delay before the next retry of a revocation that failed 'attempts' times
+func revocationBackoff(attempts int) time.Duration {
+	backoff := RevocationRetryMinBackoff
+	for i := 1; i < attempts && backoff < RevocationRetryMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > RevocationRetryMaxBackoff {
+		return RevocationRetryMaxBackoff
+	}
+	return backoff
+}
+
+// failed records a failed attempt to remove the access
+func (r *PendingRevocation) failed(err error) {
+	r.Attempts++
+	r.LastAttempt = time.Now()
+	r.NextAttempt = r.LastAttempt.Add(revocationBackoff(r.Attempts))
+	r.LastError = fmt.Sprint(err)
+}
+
+func storageKeyForRevocation(revocationID string) string {
+	return RevocationKeyPrefix + revocationID
+}
+
+func (b *BaseBackend) GetPendingRevocationFromStorage(ctx context.Context, storage logical.Storage, revocationID string) (*PendingRevocation, error) {
+	entry, err := storage.Get(ctx, storageKeyForRevocation(revocationID))
+	if err != nil {
+		return nil, fmt.Errorf("Could not retrieve the pending revocation")
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	var revocation PendingRevocation
+	if err := json.Unmarshal(entry.Value, &revocation); err != nil {
+		return nil, fmt.Errorf("The pending revocation could not be retrieved")
+	}
+	return &revocation, nil
+}
+
+func (b *BaseBackend) storePendingRevocationToStorage(ctx context.Context, storage logical.Storage, revocation *PendingRevocation) error {
+	revocationJSON, err := json.Marshal(revocation)
+	if err != nil {
+		return err
+	}
+	err = storage.Put(ctx, &logical.StorageEntry{
+		Key:   storageKeyForRevocation(revocation.ID),
+		Value: revocationJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not store the pending revocation")
+	}
+	return nil
+}
+
+// ListPendingRevocations returns the pending revocations, oldest first
+func (b *BaseBackend) ListPendingRevocations(ctx context.Context, storage logical.Storage) ([]*PendingRevocation, error) {
+	revocationIDs, err := storage.List(ctx, RevocationKeyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("Could not list the pending revocations")
+	}
+
+	revocations := []*PendingRevocation{}
+	for _, revocationID := range revocationIDs {
+		revocation, err := b.GetPendingRevocationFromStorage(ctx, storage, revocationID)
+		if err != nil {
+			return nil, err
+		}
+		if revocation != nil {
+			revocations = append(revocations, revocation)
+		}
+	}
+	sort.Slice(revocations, func(i, j int) bool {
+		return revocations[i].CreatedAt.Before(revocations[j].CreatedAt)
+	})
+	return revocations, nil
+}
+
+// queueRevocation queues the removal of the access of a revoked lease (with 'leaseData'), which failed with 'cause'.
+// If the claim is queued already, the failure is recorded on its pending revocation.
+// The caller must hold the lock of the AccessRequest.
+func (b *BaseBackend) queueRevocation(ctx context.Context, storage logical.Storage, requestorID string, leaseData map[string]interface{}, cause error) (*PendingRevocation, error) {
+	// Leases of the same claim share their queue entry
+	revocationID, _ := leaseData[ClaimIDKey].(string)
+	if revocationID == "" {
+		var err error
+		revocationID, err = uuid.GenerateUUID()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// A claim queued already keeps its age and attempts, so its backoff is not restarted
+	revocation, err := b.GetPendingRevocationFromStorage(ctx, storage, revocationID)
+	if err != nil {
+		return nil, err
+	}
+	if revocation == nil {
+		revocation = &PendingRevocation{
+			ID:          revocationID,
+			RequestorID: requestorID,
+			LeaseData:   leaseData,
+			CreatedAt:   time.Now(),
+		}
+	}
+	revocation.failed(cause)
+	if err := b.storePendingRevocationToStorage(ctx, storage, revocation); err != nil {
+		return nil, err
+	}
+	b.Logger().Warn("[!] Revocation of claimed access queued for retry",
+		"RevocationID", revocation.ID,
+		"RequestorID", requestorID,
+		"NextAttempt", revocation.NextAttempt,
+		"error", cause,
+	)
+	return revocation, nil
+}
+
+// RetryRevocations retries the pending revocations that are due, or all of them if 'force' is set.
+// It returns the IDs of the revocations that were completed.
+// It acquires the lock of each AccessRequest, so it must not be called while holding any of them.
+func (b *BaseBackend) RetryRevocations(ctx context.Context, req *logical.Request, force bool) ([]string, error) {
+	revocations, err := b.ListPendingRevocations(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := []string{}
+	for _, revocation := range revocations {
+		if !force && revocation.NextAttempt.After(time.Now()) {
+			continue
+		}
+		done, err := b.RetryRevocation(ctx, req, revocation.ID)
+		if err != nil {
+			return completed, err
+		}
+		if done {
+			completed = append(completed, revocation.ID)
+		}
+	}
+	return completed, nil
+}
+
+// RetryRevocation retries the pending revocation 'revocationID', returning whether it was completed.
+// Errors removing the access are recorded on the pending revocation, not returned.
+func (b *BaseBackend) RetryRevocation(ctx context.Context, req *logical.Request, revocationID string) (bool, error) {
+	revocation, err := b.GetPendingRevocationFromStorage(ctx, req.Storage, revocationID)
+	if err != nil || revocation == nil {
+		return false, err
+	}
+
+	lock := b.RequestLock(revocation.RequestorID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Re-read under the lock, as it may have been completed concurrently
+	revocation, err = b.GetPendingRevocationFromStorage(ctx, req.Storage, revocationID)
+	if err != nil || revocation == nil {
+		return false, err
+	}
+	accessRequest, err := b.GetRequestFromStorage(ctx, req.Storage, revocation.RequestorID)
+	if err != nil {
+		return false, err
+	}
+
+	internalData, granted := claimLeaseData(accessRequest, revocation.LeaseData)
+	if granted {
+		if _, err := b.ClaimArray.Remove(ctx, req, revocation.RequestorID, internalData); err != nil {
+			revocation.failed(err)
+			b.Logger().Warn("[!] Retry of queued revocation failed",
+				"RevocationID", revocation.ID,
+				"RequestorID", revocation.RequestorID,
+				"Attempts", revocation.Attempts,
+				"NextAttempt", revocation.NextAttempt,
+				"error", err,
+			)
+			return false, b.storePendingRevocationToStorage(ctx, req.Storage, revocation)
+		}
+	}
+
+	if accessRequest != nil &&
+		accessRequest.Status == models.Active &&
+		isClaimOfLease(*accessRequest, revocation.LeaseData) {
+		accessRequest.Revoke()
+		if err := b.StoreRequestToStorage(ctx, req.Storage, accessRequest); err != nil {
+			return false, err
+		}
+	}
+	if err := req.Storage.Delete(ctx, storageKeyForRevocation(revocation.ID)); err != nil {
+		return false, fmt.Errorf("Could not delete the pending revocation")
+	}
+	b.Logger().Info("[+] Queued revocation completed",
+		"RevocationID", revocation.ID,
+		"RequestorID", revocation.RequestorID,
+		"Attempts", revocation.Attempts,
+	)
+	return true, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// Path for listing the claims currently holding access
+func PathActive(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "active/?",
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.handleActiveList,
+		},
+		HelpSynopsis: "Lists the claims currently holding access",
+		HelpDescription: `This endpoint lists the 'active' AccessRequests, oldest claim first,
+		with the data of the access granted to them (e.g.: 'new_policies', 'okta_group_id').
+
+		'claim_id' is set by the Gate on the data of the lease of the claim, and 'time_remaining'
+		is the number of seconds until it expires. Lease IDs are not listed: Vault/OpenBao generates them
+		after the claim is responded to and passes them to plugins only when revoking the lease,
+		when they are recorded as 'lease_id' on the AccessRequest.
+
+		Active claims can be revoked using '/revoke/<requestor_id>'.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleActiveList(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	active := models.Active
+	accessRequests, _, err := b.ListRequests(ctx, req, RequestFilter{
+		Status: &active,
+	})
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	sort.Slice(accessRequests, func(i, j int) bool {
+		return accessRequests[i].ClaimCreatedAt.Before(accessRequests[j].ClaimCreatedAt)
+	})
+
+	resultsFull := map[string]interface{}{}
+	results := []string{}
+
+	now := time.Now()
+	for _, accessRequest := range accessRequests {
+		results = append(results, accessRequest.OwnerID)
+
+		claimExpiration := accessRequest.ClaimCreatedAt.Add(accessRequest.ClaimTTL)
+		responseObj := responses.ActiveClaimResponse{
+			OwnerID: accessRequest.OwnerID,
+			ClaimID: accessRequest.ClaimID,
+
+			ClaimCreatedAt:  accessRequest.ClaimCreatedAt.Unix(),
+			ClaimExpiration: claimExpiration.Unix(),
+			TimeRemaining:   int64(claimExpiration.Sub(now) / time.Second),
+
+			ClaimData:        accessRequest.ClaimData,
+			RevocationReason: accessRequest.RevocationReason,
+		}
+
+		responseData, err := StructToMap(responseObj)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprint(err)), nil
+		}
+		resultsFull[accessRequest.OwnerID] = responseData
+	}
+
+	return logical.ListResponseWithInfo(
+		results,
+		resultsFull,
+	), nil
+}
@@ -13,13 +13,28 @@ package base
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/logical"
 
 	"github.com/gateplane-io/vault-plugins/pkg/models"
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
 )
 
+var approvalCommentField = &framework.FieldSchema{
+	Type:        framework.TypeString,
+	Description: "A comment attached to the approval (e.g.: its conditions)",
+	Required:    false,
+}
+
+var approvalTTLField = &framework.FieldSchema{
+	Type:        framework.TypeDurationSecond,
+	Description: "The maximum claim TTL allowed by the approval (0 for no limit)",
+	Required:    false,
+}
+
 func PathApprove(b *BaseBackend) *framework.Path {
 	return &framework.Path{
 		Pattern: "approve/(?P<requestor_id>[^/]+)/?",
@@ -29,15 +44,34 @@ func PathApprove(b *BaseBackend) *framework.Path {
 				Description: "The RequestorID of the AccessRequest to approve",
 				Required:    false,
 			},
+			"comment": approvalCommentField,
+			"ttl":     approvalTTLField,
 		},
-		Callbacks: map[logical.Operation]framework.OperationFunc{
-			logical.UpdateOperation: b.handleApprove,
-			logical.ListOperation:   b.handleApproveList,
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleApprove,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+			logical.ListOperation: &framework.PathOperation{
+				Callback: b.handleApproveList,
+			},
+			logical.DeleteOperation: &framework.PathOperation{
+				Callback:                    b.handleApproveDelete,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
 		},
 		HelpSynopsis: "Approves the AccessRequest created by the provided RequestorID",
-		HelpDescription: `This endpoint approves AccessRequests.
+		HelpDescription: `This endpoint approves AccessRequests (using 'update'),
+		and retracts the caller's approval of AccessRequests not claimed yet (using 'delete').
 
 		'requestor_id' designates the owner of the AccessRequest to be approved.
+		Approvers can attach a 'comment', and limit the claim TTL with 'ttl'.
+		The AccessRequest is claimed for the minimum of its 'claim_ttl' and the 'ttl' of its approvals.
+
+		If a retraction makes the approvals fall below 'required_approvals',
+		the AccessRequest returns to 'pending'. Retractions are recorded in its 'history'.
 		`,
 	}
 }
@@ -53,9 +87,34 @@ func (b *BaseBackend) handleApprove(ctx context.Context, req *logical.Request, d
 	// if !ok {
 	// 	return logical.ErrorResponse(fmt.Sprint(ok)), nil
 	// }
+	comment, ttl, err := approvalFromFieldData(d)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrInvalidRequest
+	}
+	return b.approveRequest(ctx, req, requestorID, comment, ttl)
+}
 
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+// approvalFromFieldData returns the 'comment' and 'ttl' of an approval
+func approvalFromFieldData(d *framework.FieldData) (string, time.Duration, error) {
+	// TypeDurationSecond returns an integer number of seconds
+	ttl := time.Duration(d.Get("ttl").(int)) * time.Second
+	if ttl < 0 {
+		return "", 0, fmt.Errorf("'ttl' cannot be negative")
+	}
+	return strings.TrimSpace(d.Get("comment").(string)), ttl, nil
+}
+
+// approveRequest approves the AccessRequest of 'requestorID' by the caller (also used by '/approve' in bulk)
+func (b *BaseBackend) approveRequest(ctx context.Context, req *logical.Request, requestorID string, comment string, ttl time.Duration) (*logical.Response, error) {
+	entityID := req.EntityID
+
+	lock := b.RequestLock(requestorID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if resp, err := b.frozenResponse(ctx, req.Storage); resp != nil {
+		return resp, err
+	}
 
 	approverID := entityID
 
@@ -86,7 +145,7 @@ func (b *BaseBackend) handleApprove(ctx context.Context, req *logical.Request, d
 		return &logical.Response{Warnings: []string{"Request already approved by this user"}}, nil
 	}
 
-	_, _, err = accessRequest.Approve(approverID) // lastApproval
+	_, _, err = accessRequest.Approve(approverID, comment, ttl) // lastApproval
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), nil
 	}
@@ -113,8 +172,9 @@ func (b *BaseBackend) handleApproveList(ctx context.Context, req *logical.Reques
 		return logical.ErrorResponse("Listing approvals requires a 'requestor_id'"), logical.ErrPermissionDenied
 	}
 
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	lock := b.RequestLock(requestorID)
+	lock.RLock()
+	defer lock.RUnlock()
 
 	accessRequest, err := b.GetRequest(ctx, req, requestorID)
 	if err != nil {
@@ -126,18 +186,62 @@ func (b *BaseBackend) handleApproveList(ctx context.Context, req *logical.Reques
 	}
 
 	keys := make([]string, 0, len(accessRequest.Approvals))
-	for approval := range accessRequest.Approvals {
-		keys = append(keys, approval)
+	keyInfo := map[string]interface{}{}
+	entities := b.newEntityResolver(ctx, req)
+	for approverID, approval := range accessRequest.Approvals {
+		keys = append(keys, approverID)
+
+		responseData, err := StructToMap(responses.ApprovalResponse{
+			OwnerID:   approval.OwnerID,
+			Approver:  entities.Resolve(approval.OwnerID),
+			CreatedAt: approval.CreatedAt.Unix(),
+			Comment:   approval.Comment,
+			TTL:       approval.TTL / time.Second,
+		})
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprint(err)), nil
+		}
+		keyInfo[approverID] = responseData
 	}
 
-	// Use this instead of ListResponseWithInfo,
-	// as it automatically serializes:
-	// accessRequest.Approvals
+	return logical.ListResponseWithInfo(keys, keyInfo), nil
+
+}
+
+func (b *BaseBackend) handleApproveDelete(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	approverID := req.EntityID
+	if approverID == "" {
+		return logical.ErrorResponse("Token has no EntityID assigned"), logical.ErrPermissionDenied
+	}
+	requestorID := d.Get("requestor_id").(string)
+
+	lock := b.RequestLock(requestorID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	accessRequest, err := b.GetRequest(ctx, req, requestorID)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	if accessRequest == nil {
+		return &logical.Response{Warnings: []string{"Request does not exist"}}, nil
+	}
+
+	if err := accessRequest.RetractApproval(approverID); err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	if err := b.StoreRequest(ctx, req, accessRequest); err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	b.Logger().Info("[+] Approval retracted",
+		"RequestorID", requestorID,
+		"ApproverID", approverID,
+		"Status", accessRequest.Status,
+	)
+
 	return &logical.Response{
 		Data: map[string]interface{}{
-			"keys":     keys,
-			"key_info": accessRequest.Approvals,
+			"status": accessRequest.Status,
 		},
 	}, nil
-
 }
@@ -0,0 +1,224 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// bulkFields selects the AccessRequests of a bulk operation
+func bulkFields() map[string]*framework.FieldSchema {
+	return map[string]*framework.FieldSchema{
+		"requestor_ids": {
+			Type:        framework.TypeCommaStringSlice,
+			Description: "The RequestorIDs of the AccessRequests (the filters are used if unset)",
+			Required:    false,
+		},
+		"status": {
+			Type:        framework.TypeLowerCaseString,
+			Description: "Select the AccessRequests in this status",
+			Required:    false,
+		},
+		"created_after": {
+			Type:        framework.TypeTime,
+			Description: "Select the AccessRequests created after this time (RFC3339 or Unix time)",
+			Required:    false,
+		},
+		"created_before": {
+			Type:        framework.TypeTime,
+			Description: "Select the AccessRequests created before this time (RFC3339 or Unix time)",
+			Required:    false,
+		},
+	}
+}
+
+// Path for approving AccessRequests in bulk
+func PathApproveBulk(b *BaseBackend) *framework.Path {
+	fields := bulkFields()
+	fields["comment"] = approvalCommentField
+	fields["ttl"] = approvalTTLField
+	return &framework.Path{
+		Pattern: "approve/?",
+		Fields:  fields,
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleApproveBulk,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+		},
+		HelpSynopsis: "Approves AccessRequests in bulk",
+		HelpDescription: `This endpoint approves the AccessRequests of 'requestor_ids',
+		or the ones matching 'status' (default: 'pending'), 'created_after' and 'created_before'.
+
+		Each AccessRequest is approved as by '/approve/<requestor_id>' (with 'comment' and 'ttl'), and the response
+		contains the result for each of them. A failure does not abort the rest.
+		`,
+	}
+}
+
+// Path for rejecting AccessRequests in bulk
+func PathRejectBulk(b *BaseBackend) *framework.Path {
+	fields := bulkFields()
+	fields["reason"] = &framework.FieldSchema{
+		Type:        framework.TypeString,
+		Description: "Why the AccessRequests are rejected",
+		Required:    false,
+	}
+	return &framework.Path{
+		Pattern: "reject/?",
+		Fields:  fields,
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleRejectBulk,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+		},
+		HelpSynopsis: "Rejects AccessRequests in bulk",
+		HelpDescription: `This endpoint rejects the AccessRequests of 'requestor_ids',
+		or the ones matching 'status' (default: 'pending' and 'approved'), 'created_after' and 'created_before'.
+
+		Each AccessRequest is rejected as by '/reject/<requestor_id>', and the response
+		contains the result for each of them. A failure does not abort the rest.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleApproveBulk(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	comment, ttl, err := approvalFromFieldData(d)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrInvalidRequest
+	}
+	return b.runBulk(ctx, req, d, []models.AccessRequestStatus{models.Pending},
+		func(requestorID string) (*logical.Response, error) {
+			return b.approveRequest(ctx, req, requestorID, comment, ttl)
+		},
+	)
+}
+
+func (b *BaseBackend) handleRejectBulk(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	reason := strings.TrimSpace(d.Get("reason").(string))
+	return b.runBulk(ctx, req, d, []models.AccessRequestStatus{models.Pending, models.Approved},
+		func(requestorID string) (*logical.Response, error) {
+			return b.rejectRequest(ctx, req, requestorID, reason)
+		},
+	)
+}
+
+// runBulk runs 'operation' on each selected AccessRequest, selected by filter in 'defaultStatuses'
+// if no status is given, and returns the result for each of them
+func (b *BaseBackend) runBulk(ctx context.Context, req *logical.Request, d *framework.FieldData, defaultStatuses []models.AccessRequestStatus, operation func(string) (*logical.Response, error)) (*logical.Response, error) {
+	if req.EntityID == "" {
+		return logical.ErrorResponse("Token has no EntityID assigned"), logical.ErrPermissionDenied
+	}
+
+	requestorIDs, err := b.bulkRequestorIDs(ctx, req, d, defaultStatuses)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrInvalidRequest
+	}
+
+	responseObj := responses.BulkResponse{
+		Results: map[string]responses.BulkItemResponse{},
+	}
+	for _, requestorID := range requestorIDs {
+		result := bulkItemResult(operation(requestorID))
+		if result.Success {
+			responseObj.Succeeded++
+		} else {
+			responseObj.Failed++
+		}
+		responseObj.Results[requestorID] = result
+	}
+	b.Logger().Info("[+] Bulk operation on AccessRequests",
+		"Path", req.Path,
+		"EntityID", req.EntityID,
+		"Succeeded", responseObj.Succeeded,
+		"Failed", responseObj.Failed,
+	)
+
+	responseData, err := StructToMap(responseObj)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	return &logical.Response{Data: responseData}, nil
+}
+
+// bulkRequestorIDs returns the 'requestor_ids', or the IDs of the AccessRequests matching the filters
+func (b *BaseBackend) bulkRequestorIDs(ctx context.Context, req *logical.Request, d *framework.FieldData, defaultStatuses []models.AccessRequestStatus) ([]string, error) {
+	if requestorIDs := d.Get("requestor_ids").([]string); len(requestorIDs) > 0 {
+		return requestorIDs, nil
+	}
+
+	filter := RequestFilter{}
+	statuses := defaultStatuses
+	statusString := d.Get("status").(string)
+	if statusString != "" {
+		status, err := models.ParseAccessRequestStatus(statusString)
+		if err != nil {
+			return nil, err
+		}
+		statuses = []models.AccessRequestStatus{status}
+	}
+	if createdAfter, ok := d.GetOk("created_after"); ok {
+		filter.CreatedAfter = createdAfter.(time.Time)
+	}
+	if createdBefore, ok := d.GetOk("created_before"); ok {
+		filter.CreatedBefore = createdBefore.(time.Time)
+	}
+	if statusString == "" && filter.CreatedAfter.IsZero() && filter.CreatedBefore.IsZero() {
+		return nil, fmt.Errorf("Either 'requestor_ids' or a filter ('status', 'created_after', 'created_before') is required")
+	}
+
+	requestorIDs := []string{}
+	for i := range statuses {
+		filter.Status = &statuses[i]
+		accessRequests, _, err := b.ListRequests(ctx, req, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, accessRequest := range accessRequests {
+			requestorIDs = append(requestorIDs, accessRequest.OwnerID)
+		}
+	}
+	return requestorIDs, nil
+}
+
+// bulkItemResult converts the response of an operation on a single AccessRequest
+func bulkItemResult(resp *logical.Response, err error) responses.BulkItemResponse {
+	result := responses.BulkItemResponse{}
+	if resp != nil {
+		result.Warnings = resp.Warnings
+		if resp.IsError() {
+			result.Error = fmt.Sprint(resp.Error())
+			return result
+		}
+		if status, ok := resp.Data["status"]; ok {
+			result.Status = fmt.Sprint(status)
+		}
+	}
+	if err != nil {
+		result.Error = fmt.Sprint(err)
+		return result
+	}
+	// Responses without a status are warnings (e.g.: the AccessRequest does not exist)
+	result.Success = result.Status != ""
+	return result
+}
@@ -24,8 +24,14 @@ import (
 func PathClaim(b *BaseBackend) *framework.Path {
 	return &framework.Path{
 		Pattern: "claim",
-		Callbacks: map[logical.Operation]framework.OperationFunc{
-			logical.UpdateOperation: b.handleClaim,
+		Operations: map[logical.Operation]framework.OperationHandler{
+			// The Append hook grants access before storage is written,
+			// so claims must never start on a node with read-only storage
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleClaim,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
 		},
 		HelpSynopsis: "Provides access for an approved AccessRequest.",
 		HelpDescription: `This endpoint reads a user's AccessRequest
@@ -43,11 +49,19 @@ func (b *BaseBackend) handleClaim(ctx context.Context, req *logical.Request, d *
 		return logical.ErrorResponse("Token has no EntityID assigned"), logical.ErrPermissionDenied
 	}
 
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
-
 	requestorID := req.EntityID
 
+	// Only this requestor's lock is held while the Append hook reaches
+	// the external API, so claims of other requestors are not blocked.
+	lock := b.RequestLock(requestorID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Checked under the lock, so '/kill' revokes any claim that passed it
+	if resp, err := b.frozenResponse(ctx, req.Storage); resp != nil {
+		return resp, err
+	}
+
 	accessRequest, err := b.GetRequest(ctx, req, requestorID)
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), nil
@@ -67,40 +81,86 @@ func (b *BaseBackend) handleClaim(ctx context.Context, req *logical.Request, d *
 		), nil
 	}
 
+	// The access held before the claim is kept if it is rolled back
+	previous, err := b.ClaimArray.Snapshot(ctx, req, accessRequest.OwnerID, accessRequest.Access)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+
+	// Access granted from now on is rolled back, unless the claim gets its lease
+	walID, claimID, err := b.putClaimWAL(ctx, req, accessRequest, previous)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+
 	b.Logger().Info("[+] Claiming access through the Lease Append hook",
 		"RequestorID", accessRequest.OwnerID,
+		"ClaimID", claimID,
 	)
-	internalData, err := b.ClaimArray.Append(ctx, req, accessRequest.OwnerID)
+	internalData, err := b.ClaimArray.Append(ctx, req, accessRequest.OwnerID, accessRequest.Access)
 	if err != nil {
+		// The access may be partially granted, so it is rolled back right away
+		if err2 := b.ClaimArray.Rollback(ctx, req, accessRequest.OwnerID, accessRequest.Access, previous); err2 != nil {
+			b.Logger().Error("[-] Could not roll back the access of a failed claim, leaving it to the WAL rollback",
+				"RequestorID", accessRequest.OwnerID,
+				"ClaimID", claimID,
+				"error", err2,
+			)
+			return logical.ErrorResponse(fmt.Sprint(err)), nil
+		}
+		if err2 := framework.DeleteWAL(ctx, req.Storage, walID); err2 != nil {
+			b.Logger().Warn("[!] Could not delete the WAL entry of a rolled back claim",
+				"RequestorID", accessRequest.OwnerID,
+				"error", err2,
+			)
+		}
 		return logical.ErrorResponse(fmt.Sprint(err)), nil
 	}
 	internalData["requestor_id"] = accessRequest.OwnerID
+	internalData[ClaimIDKey] = claimID
 	b.Logger().Info("[+] Updating AccessRequest status to 'Active'",
 		"RequestorID", accessRequest.OwnerID,
 		"InternalData", internalData,
 	)
 
-	accessRequest.Claim()
+	accessRequest.Claim(claimID)
+	internalData[ClaimCreatedAtKey] = accessRequest.ClaimCreatedAt.Format(time.RFC3339Nano)
+	accessRequest.ClaimData = internalData
 	err = b.StoreRequest(ctx, req, accessRequest)
 	if err != nil {
 		_, err2 := b.ClaimArray.Remove(ctx, req, accessRequest.OwnerID, internalData)
 		if err2 != nil {
 			return logical.ErrorResponse(fmt.Sprint(err2)), nil
 		}
+		if err2 := framework.DeleteWAL(ctx, req.Storage, walID); err2 != nil {
+			b.Logger().Warn("[!] Could not delete the WAL entry of a removed claim",
+				"RequestorID", accessRequest.OwnerID,
+				"error", err2,
+			)
+		}
 		return logical.ErrorResponse(fmt.Sprint(err)), nil
 	}
+	// No lease will be returned if the WAL entry remains, so the claim is left to be rolled back
+	if err := framework.DeleteWAL(ctx, req.Storage, walID); err != nil {
+		b.Logger().Error("[-] Could not delete the WAL entry of the claim",
+			"RequestorID", accessRequest.OwnerID,
+			"ClaimID", claimID,
+			"error", err,
+		)
+		return logical.ErrorResponse("Could not complete the claim, its access will be rolled back"), nil
+	}
 	// data := NormalizeMapStrings(internalData)
 	b.Logger().Info("[+] Creating Response Secret",
 		"RequestorID", accessRequest.OwnerID,
 		// "Data", data,
 		"InternalData", internalData,
-		"AccessRequest", accessRequest,
+		"Status", accessRequest.Status,
 	)
 
 	respSecret := b.Secret(SecretType)
 	respSecret.Type = SecretType
 	resp := respSecret.Response(internalData, internalData)
-	resp.Secret.TTL = accessRequest.ClaimTTL * time.Second
+	resp.Secret.TTL = accessRequest.ClaimTTL
 	b.Logger().Warn("[+] Claimed AccessRequest",
 		"RequestorID", accessRequest.OwnerID,
 		"ClaimTime", accessRequest.ClaimCreatedAt,
@@ -128,43 +188,69 @@ func (b *BaseBackend) handleClaimRevocation(ctx context.Context, req *logical.Re
 		"Revoker", req.DisplayName,
 	)
 
-	// The lease's InternalData is the durable source of truth for cleanup. Run
-	// removal even if the mutable AccessRequest record is stale or missing.
-	removed, err := b.ClaimArray.Remove(ctx, req, requestorID, req.Secret.InternalData)
+	lock := b.RequestLock(requestorID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	accessRequest, err := b.GetRequest(ctx, req, requestorID)
 	if err != nil {
-		return logical.ErrorResponse(fmt.Sprint(err)), nil
+		// Removal does not depend on the AccessRequest, it is only updated afterwards
+		b.Logger().Error("[-] Could not read AccessRequest of revoked claim",
+			"RequestorID", requestorID,
+			"error", err,
+		)
+		accessRequest = nil
 	}
-	if !removed {
-		return logical.ErrorResponse("Claimed access was not removed"), nil
+	if accessRequest != nil && req.Secret.LeaseID != "" &&
+		accessRequest.LeaseID != req.Secret.LeaseID &&
+		isClaimOfLease(*accessRequest, req.Secret.InternalData) {
+		// Lease IDs are not passed to the Gate before their revocation, so they are recorded on it
+		accessRequest.LeaseID = req.Secret.LeaseID
+		if err := b.StoreRequest(ctx, req, accessRequest); err != nil {
+			b.Logger().Warn("[!] Could not record the lease ID of the revoked claim",
+				"RequestorID", requestorID,
+				"error", err,
+			)
+		}
 	}
 
-	accessRequest, err := b.GetRequest(ctx, req, requestorID)
+	// The lease's InternalData is the durable source of truth for cleanup. Run
+	// removal even if the mutable AccessRequest record is stale or missing,
+	// unless the access of the lease was already removed by the plugin.
+	internalData, granted := claimLeaseData(accessRequest, req.Secret.InternalData)
+	if !granted {
+		b.Logger().Info("[*] Access of the revoked claim was already removed",
+			"RequestorID", requestorID,
+		)
+		return nil, nil
+	}
+	removed, err := b.ClaimArray.Remove(ctx, req, requestorID, internalData)
+	if err == nil && !removed {
+		err = fmt.Errorf("Claimed access was not removed")
+	}
 	if err != nil {
-		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrNotFound
+		// The removal is retried by the revocation queue, which then updates the AccessRequest
+		if _, err2 := b.queueRevocation(ctx, req.Storage, requestorID, req.Secret.InternalData, err); err2 != nil {
+			return logical.ErrorResponse(fmt.Sprint(err)), nil
+		}
+		return nil, nil
 	}
+
 	if accessRequest == nil {
 		b.Logger().Warn("[!] AccessRequest missing after claimed access was removed",
 			"RequestorID", requestorID,
 		)
 		return nil, nil
 	}
-
-	accessRequest.Status = models.Revoked
-	/*
-		If the revocation happened by a nameless token
-		and close to the real expiration (2s) we assume that
-		the request is made by Vault/OpenBao core.
-		This sets the request as Expired
-		(Revoked is when it is manually revoked)
-	*/
-	if entityID == "" &&
-		req.DisplayName == "" &&
-		areDatesClose(
-			time.Now(),
-			req.Secret.LeaseOptions.ExpirationTime(),
-			time.Second*2) {
-		accessRequest.Status = models.Expired
+	if !isClaimOfLease(*accessRequest, req.Secret.InternalData) {
+		b.Logger().Info("[*] AccessRequest was replaced after the revoked claim",
+			"RequestorID", requestorID,
+			"Status", accessRequest.Status,
+		)
+		return nil, nil
 	}
+
+	accessRequest.Revoke()
 	if err := b.StoreRequest(ctx, req, accessRequest); err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), nil
 	}
@@ -174,6 +260,7 @@ func (b *BaseBackend) handleClaimRevocation(ctx context.Context, req *logical.Re
 		"EntityID", entityID,
 		"LeaseExpiration", req.Secret.LeaseOptions.ExpirationTime(),
 		"Status", accessRequest.Status,
+		"Reason", accessRequest.RevocationReason,
 		"TTL", req.Secret.TTL,
 	)
 
@@ -0,0 +1,149 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// RequestCommentEventType is the type of the plugin event sent when a comment is added to an AccessRequest
+const RequestCommentEventType = "gateplane/request-comment"
+
+func PathRequestComments(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "request/comments/(?P<requestor_id>[^/]+)/?",
+		Fields: map[string]*framework.FieldSchema{
+			"requestor_id": {
+				Type:        framework.TypeString,
+				Description: "The RequestorID of the AccessRequest to comment on",
+				Required:    true,
+			},
+			"comment": {
+				Type:        framework.TypeString,
+				Description: "The comment to append to the discussion of the AccessRequest (up to 4096 bytes)",
+				Required:    false,
+			},
+		},
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: b.handleRequestCommentsRead,
+			},
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleRequestCommentsUpdate,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+		},
+		HelpSynopsis: "Discussion between the requestor and the approvers of an AccessRequest",
+		HelpDescription: `This endpoint reads and appends to the comments of an AccessRequest,
+		which are also returned when reading the AccessRequest, and recorded in its 'history'.
+
+		The requestor can comment on their own AccessRequest, while any other entity commenting
+		is considered an approver (which entities can comment is governed by the Vault/OpenBao policies on this endpoint).
+		Comments are accepted only while the AccessRequest is 'pending', 'approved' or 'active',
+		up to 100 comments of up to 4096 bytes each per AccessRequest.
+
+		Each comment emits a 'gateplane/request-comment' plugin event, carrying
+		the 'requestor_id', 'author_id' and 'role' of the comment.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleRequestCommentsRead(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	requestorID := d.Get("requestor_id").(string)
+
+	lock := b.RequestLock(requestorID)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	accessRequest, err := b.GetRequest(ctx, req, requestorID)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	if accessRequest == nil {
+		return &logical.Response{Warnings: []string{"Request does not exist"}}, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"comments": newRequestCommentsResponse(accessRequest.Comments),
+		},
+	}, nil
+}
+
+func (b *BaseBackend) handleRequestCommentsUpdate(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	if req.EntityID == "" {
+		return logical.ErrorResponse("Token has no EntityID assigned"), logical.ErrPermissionDenied
+	}
+	authorID := req.EntityID
+	requestorID := d.Get("requestor_id").(string)
+	comment := strings.TrimSpace(d.Get("comment").(string))
+
+	lock := b.RequestLock(requestorID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	accessRequest, err := b.GetRequest(ctx, req, requestorID)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	if accessRequest == nil {
+		return &logical.Response{Warnings: []string{"Request does not exist"}}, nil
+	}
+
+	requestComment, err := accessRequest.AddComment(authorID, comment)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrInvalidRequest
+	}
+	if err := b.StoreRequest(ctx, req, accessRequest); err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	b.Logger().Info("[+] Comment added to AccessRequest",
+		"RequestorID", requestorID,
+		"AuthorID", authorID,
+		"Role", requestComment.Role,
+	)
+
+	// Events are best-effort, and not sent at all when the events system is not enabled
+	if err := logical.SendEvent(ctx, b, RequestCommentEventType,
+		logical.EventMetadataPath, req.Path,
+		logical.EventMetadataDataPath, req.Path,
+		logical.EventMetadataOperation, string(req.Operation),
+		logical.EventMetadataModified, "true",
+		"requestor_id", requestorID,
+		"author_id", authorID,
+		"role", requestComment.Role,
+	); err != nil && !errors.Is(err, framework.ErrNoEvents) {
+		b.Logger().Warn("[!] Could not send the comment event",
+			"RequestorID", requestorID,
+			"error", err,
+		)
+	}
+
+	responseData, err := StructToMap(responses.RequestCommentResponse{
+		AuthorID:  requestComment.AuthorID,
+		Role:      requestComment.Role,
+		Comment:   requestComment.Comment,
+		CreatedAt: requestComment.CreatedAt.Unix(),
+	})
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	return &logical.Response{Data: responseData}, nil
+}
@@ -0,0 +1,302 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+const ConfigBundleKey = "config/bundle"
+
+// Path for reading and writing all configurations of the backend at once
+func PathConfigBundle(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: ConfigBundleKey,
+		Fields: map[string]*framework.FieldSchema{
+			"configuration": {
+				Type:        framework.TypeMap,
+				Description: "The configurations to apply, by configuration path (e.g: 'config/lease')",
+				Required:    true,
+			},
+			ConfigDryRunKey: ConfigDryRunField,
+		},
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.handleConfigBundleUpdate,
+			logical.ReadOperation:   b.handleConfigBundleRead,
+		},
+
+		HelpSynopsis: "Reads and applies all configurations of this backend as one document",
+		HelpDescription: `This endpoint reads (using 'read') and applies (using 'update')
+		all configurations of this backend as one document, keyed by configuration path
+		(e.g: 'config', 'config/lease', 'config/access').
+
+		Every provided configuration is applied as a whole: its omitted values are set to their defaults,
+		except for write-only values (e.g: API tokens), which are kept.
+		Configurations that are not provided are left unchanged.
+
+		All configurations are validated together and stored all or none.
+		The response contains the changed values of each configuration.
+
+		'dry_run' validates the configurations and returns the changes, without storing them.
+		`,
+	}
+}
+
+// Path for resetting all configurations of the backend to their defaults
+func PathConfigBundleReset(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: ConfigBundleKey + "/reset",
+		Fields: map[string]*framework.FieldSchema{
+			ConfigDryRunKey: ConfigDryRunField,
+		},
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.handleConfigBundleReset,
+		},
+
+		HelpSynopsis: "Resets all configurations of this backend to their defaults",
+		HelpDescription: `This endpoint resets all configurations of this backend to their defaults,
+		including the API credentials.
+
+		The response contains the changed values of each configuration.
+
+		'dry_run' returns the changes, without storing them.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleConfigBundleRead(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	b.ConfigMutex.RLock()
+	defer b.ConfigMutex.RUnlock()
+
+	responseObj := responses.ConfigBundleResponse{
+		Configuration: map[string]map[string]interface{}{},
+	}
+	for _, section := range b.ConfigSections {
+		config, err := b.getConfigSectionFromStorage(ctx, req.Storage, section)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+		}
+		sectionResp, err := section.Response(config)
+		if err != nil {
+			return sectionResp, err
+		}
+		responseObj.Configuration[section.Key] = sectionResp.Data
+	}
+
+	responseData, err := StructToMap(responseObj)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+
+	return &logical.Response{Data: responseData}, nil
+}
+
+func (b *BaseBackend) handleConfigBundleUpdate(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	document := d.Get("configuration").(map[string]interface{})
+	dryRun := d.Get(ConfigDryRunKey).(bool)
+
+	b.ConfigMutex.Lock()
+	configs, diff, warnings, err := b.buildConfigBundle(ctx, req, document)
+	var proposal *ConfigProposal
+	if err == nil && !dryRun {
+		proposal, err = b.storeOrProposeConfigurations(ctx, req, configs, diff)
+	}
+	b.ConfigMutex.Unlock()
+	if err != nil {
+		return ConfigurationErrorResponse(err)
+	}
+	if proposal != nil {
+		return ConfigProposalResponse(proposal, req.EntityID)
+	}
+
+	b.Logger().Info("[+] Configuration bundle applied",
+		"EntityID", req.EntityID,
+		"DryRun", dryRun,
+		"Changed", len(configs),
+	)
+	if !dryRun {
+		warnings = append(warnings, b.runConfigSectionsApplied(ctx, req, configs)...)
+	}
+	return configChangesResponse(!dryRun, diff, warnings)
+}
+
+func (b *BaseBackend) handleConfigBundleReset(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	dryRun := d.Get(ConfigDryRunKey).(bool)
+
+	configs := map[string]PluginConfig{}
+	diff := map[string]map[string]responses.ConfigChangeResponse{}
+
+	b.ConfigMutex.Lock()
+	var err error
+	for _, section := range b.ConfigSections {
+		var current PluginConfig
+		current, err = b.getConfigSectionFromStorage(ctx, req.Storage, section)
+		if err != nil {
+			break
+		}
+		defaults := section.Default()
+		if sectionDiff := ConfigurationDiff(current, defaults); len(sectionDiff) > 0 {
+			configs[section.Key] = defaults
+			diff[section.Key] = sectionDiff
+		}
+	}
+	var proposal *ConfigProposal
+	if err == nil && !dryRun {
+		proposal, err = b.storeOrProposeConfigurations(ctx, req, configs, diff)
+	}
+	b.ConfigMutex.Unlock()
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	if proposal != nil {
+		return ConfigProposalResponse(proposal, req.EntityID)
+	}
+
+	b.Logger().Warn("[+] Configuration reset to defaults",
+		"EntityID", req.EntityID,
+		"DryRun", dryRun,
+		"Changed", len(configs),
+	)
+	warnings := []string{}
+	if !dryRun {
+		warnings = b.runConfigSectionsApplied(ctx, req, configs)
+	}
+	return configChangesResponse(!dryRun, diff, warnings)
+}
+
+// getConfigSectionFromStorage returns the stored configuration of 'section', or its defaults if it is not stored
+func (b *BaseBackend) getConfigSectionFromStorage(ctx context.Context, storage logical.Storage, section ConfigSection) (PluginConfig, error) {
+	config := section.Default()
+
+	entry, err := storage.Get(ctx, section.Key)
+	if err != nil {
+		b.Logger().Error("[-] Could not retrieve configuration from storage",
+			"path", section.Key,
+			"error", err,
+		)
+		return nil, fmt.Errorf("Could not retrieve configuration from BaseBackend")
+	}
+	if entry == nil {
+		return config, nil
+	}
+
+	if err := json.Unmarshal(entry.Value, config); err != nil {
+		b.Logger().Error("[-] Failed to unmarshal Config",
+			"path", section.Key,
+			"error", err,
+		)
+		return nil, fmt.Errorf("Configuration could not be retrieved")
+	}
+	return config, nil
+}
+
+// buildConfigBundle validates the configurations of 'document' and returns the changed ones
+// along with their changes. The caller must hold 'ConfigMutex'.
+func (b *BaseBackend) buildConfigBundle(ctx context.Context, req *logical.Request, document map[string]interface{}) (map[string]PluginConfig, map[string]map[string]responses.ConfigChangeResponse, []string, error) {
+	configs := map[string]PluginConfig{}
+	diff := map[string]map[string]responses.ConfigChangeResponse{}
+	warnings := []string{}
+	candidates := []PluginConfig{}
+
+	for key := range document {
+		if _, ok := b.configSection(key); !ok {
+			return nil, nil, nil, &UnknownConfigKeyError{Key: key}
+		}
+	}
+
+	for _, section := range b.ConfigSections {
+		rawSection, ok := document[section.Key]
+		if !ok {
+			continue
+		}
+		values, ok := rawSection.(map[string]interface{})
+		if !ok {
+			return nil, nil, nil, &ConfigTypeError{Key: section.Key, Expected: "map"}
+		}
+		for key := range values {
+			if _, ok := section.Fields[key]; !ok && key != ConfigDryRunKey {
+				warnings = append(warnings, fmt.Sprintf("'%s': ignored '%s', as it is not configurable", section.Key, key))
+			}
+		}
+
+		current, err := b.getConfigSectionFromStorage(ctx, req.Storage, section)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		candidate := section.Default()
+		keepConfigurationFields(candidate, current, values, section.Fields)
+
+		err = ApplyConfigurationFields(b, req, candidate, &framework.FieldData{Raw: values, Schema: section.Fields})
+		if err == nil {
+			err = candidate.Validate()
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("'%s': %w", section.Key, err)
+		}
+		candidates = append(candidates, candidate)
+
+		if sectionDiff := ConfigurationDiff(current, candidate); len(sectionDiff) > 0 {
+			configs[section.Key] = candidate
+			diff[section.Key] = sectionDiff
+		}
+	}
+
+	if err := b.validateConfigurationConsistency(ctx, req.Storage, candidates...); err != nil {
+		return nil, nil, nil, err
+	}
+	return configs, diff, warnings, nil
+}
+
+func (b *BaseBackend) configSection(key string) (ConfigSection, bool) {
+	for _, section := range b.ConfigSections {
+		if section.Key == key {
+			return section, true
+		}
+	}
+	return ConfigSection{}, false
+}
+
+// runConfigSectionsApplied calls the 'Applied' function of the sections of the stored 'configs'
+func (b *BaseBackend) runConfigSectionsApplied(ctx context.Context, req *logical.Request, configs map[string]PluginConfig) []string {
+	warnings := []string{}
+	for _, section := range b.ConfigSections {
+		config, ok := configs[section.Key]
+		if !ok || section.Applied == nil {
+			continue
+		}
+		warnings = append(warnings, section.Applied(ctx, req, config)...)
+	}
+	if _, ok := configs[b.AccessConfigKey]; ok {
+		warnings = append(warnings, b.AccessChanged(ctx, req)...)
+	}
+	return warnings
+}
+
+func configChangesResponse(applied bool, diff map[string]map[string]responses.ConfigChangeResponse, warnings []string) (*logical.Response, error) {
+	responseObj := responses.ConfigBundleApplyResponse{
+		Applied: applied,
+		Diff:    diff,
+	}
+
+	responseData, err := StructToMap(responseObj)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+
+	return &logical.Response{Data: responseData, Warnings: warnings}, nil
+}
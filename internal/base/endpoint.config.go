@@ -45,6 +45,48 @@ func PathConfig(b *BaseBackend) *framework.Path {
 				Description: "Required number of approvals before claiming.",
 				Required:    false,
 			},
+			"encrypt_requests": {
+				Type:        framework.TypeBool,
+				Description: "Whether AccessRequests are encrypted in storage with a plugin-managed key.",
+				Required:    false,
+			},
+			"protect_config": {
+				Type:        framework.TypeBool,
+				Description: "Whether configuration changes require approval.",
+				Required:    false,
+			},
+			"config_required_approvals": {
+				Type:        framework.TypeInt,
+				Description: "Required number of approvals before applying a configuration change.",
+				Required:    false,
+			},
+			"config_approvers": {
+				Type:        framework.TypeCommaStringSlice,
+				Description: "Entity IDs and identity group names or IDs allowed to approve configuration changes.",
+				Required:    false,
+			},
+			"reset_approvals_on_access_change": {
+				Type:        framework.TypeBool,
+				Description: "Whether approvals are reset when the access configuration changes.",
+				Required:    false,
+			},
+			"on_config_change": {
+				Type:          framework.TypeLowerCaseString,
+				Description:   "What happens to active claims when the access configuration changes ('keep', 'regrant' or 'revoke').",
+				Required:      false,
+				AllowedValues: []interface{}{OnConfigChangeKeep, OnConfigChangeRegrant, OnConfigChangeRevoke},
+			},
+			"reconcile_interval": {
+				Type:        framework.TypeDurationSecond,
+				Description: "How often claims are reconciled with the granted access (0 disables it).",
+				Required:    false,
+			},
+			"entity_metadata_keys": {
+				Type:        framework.TypeCommaStringSlice,
+				Description: "Metadata keys of the requestor and approver entities returned in responses.",
+				Required:    false,
+			},
+			ConfigDryRunKey: ConfigDryRunField,
 		},
 		Callbacks: map[logical.Operation]framework.OperationFunc{
 			logical.UpdateOperation: b.handleConfigUpdate,
@@ -59,55 +101,89 @@ func PathConfig(b *BaseBackend) *framework.Path {
 		'request_ttl' and 'delete_after' configure the lifetime of AccessRequests.
 
 		'required_approvals' sets the number of approvals for an AccessRequest required to reach the 'approved' state (can be positive integer or 0).
+
+		'encrypt_requests' encrypts AccessRequests (including their 'justification') in storage,
+		with an AES-GCM key kept seal-wrapped by the plugin (see '/keys/requests').
+		Existing AccessRequests are (re/de)-encrypted by the periodic tidy.
+
+		AccessRequests snapshot the access configuration of the Gate (e.g: '/config/access') when created,
+		and claims grant exactly the snapshotted access. 'reset_approvals_on_access_change' returns
+		approved and pending AccessRequests to 'pending' without approvals when the access configuration changes,
+		so that the new access has to be approved.
+
+		'on_config_change' sets what happens to active claims when the access configuration changes:
+		'keep' leaves the granted access until the lease ends, 'regrant' replaces it with the new access
+		and 'revoke' removes it (the lease remains, but grants nothing).
+
+		'reconcile_interval' sets how often the granted access is compared with the claims (see '/reconcile').
+
+		'entity_metadata_keys' selects the metadata of the requestor and approver entities
+		returned in responses, alongside their names and alias names.
+
+		'protect_config' makes the writes to the configuration endpoints (including this one) create ConfigProposals,
+		that are applied once approved by 'config_required_approvals' Entities (see '/config/proposal').
+		Enabling it applies immediately, while disabling it requires approval.
+		Only the Entities listed in 'config_approvers', or members of the identity groups listed in it,
+		can approve ConfigProposals, so it cannot be empty while 'protect_config' is set.
+
+		'delete_after' cannot be shorter than 'request_ttl' and 'lease_max' (under '/config/lease') combined.
+
+		'dry_run' validates the configuration and returns it, without storing it.
 		`,
 	}
 }
 
+// Section of '/config/bundle' for the plugin configuration
+func ConfigSectionConfig(b *BaseBackend) ConfigSection {
+	return ConfigSection{
+		Key:    ConfigKey,
+		Fields: PathConfig(b).Fields,
+		Default: func() PluginConfig {
+			config := NewConfig()
+			return &config
+		},
+		Response: func(config PluginConfig) (*logical.Response, error) {
+			return configResponse(config.(*Config))
+		},
+	}
+}
+
 func (b *BaseBackend) handleConfigUpdate(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
-	entityID := req.EntityID
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	b.ConfigMutex.Lock()
+	defer b.ConfigMutex.Unlock()
 
 	config, err := GetConfiguration[*Config](ctx, b, req, "")
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
-	for key := range d.Raw {
-		value, ok := d.GetOk(key)
-		if !ok {
-			continue
-		}
-		b.Logger().Info("[*] Replacing configuration value",
-			"Path", req.Path,
-			"EntityID", entityID,
-			"ConfigKey", key,
-			// "OldValue", config,
-			"NewValue", value,
-		)
-
-		err := config.SetConfigurationKey(key, value)
-		if err != nil {
-			return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrPermissionDenied
-		}
-	}
-	err = StoreConfiguration[*Config](ctx, b, req, config, "")
+	proposal, stored, err := UpdateConfiguration(ctx, b, req, d, config, "")
 	if err != nil {
-		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+		return ConfigurationErrorResponse(err)
+	}
+	if proposal != nil {
+		return ConfigProposalResponse(proposal, req.EntityID)
+	}
+	if !stored {
+		return configResponse(config)
 	}
 
 	return &logical.Response{}, nil
 }
 
 func (b *BaseBackend) handleConfigRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	b.ConfigMutex.RLock()
+	defer b.ConfigMutex.RUnlock()
 
 	config, err := GetConfiguration[*Config](ctx, b, req, "")
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
+	return configResponse(config)
+}
+
+func configResponse(config *Config) (*logical.Response, error) {
 	responseObj := responses.ConfigResponse{
 		RequiredApprovals:    config.RequiredApprovals,
 		RequireJustification: config.RequireJustification,
@@ -116,6 +192,19 @@ func (b *BaseBackend) handleConfigRead(ctx context.Context, req *logical.Request
 		// If I need seconds
 		RequestTTL:  config.RequestTTL.Seconds(),
 		DeleteAfter: config.DeleteAfter.Seconds(),
+
+		EncryptRequests: config.EncryptRequests,
+
+		ProtectConfig:           config.ProtectConfig,
+		ConfigRequiredApprovals: config.ConfigRequiredApprovals,
+		ConfigApprovers:         config.ConfigApprovers,
+
+		ResetApprovalsOnAccessChange: config.ResetApprovalsOnAccessChange,
+		OnConfigChange:               config.OnConfigChange,
+
+		ReconcileInterval: config.ReconcileInterval.Seconds(),
+
+		EntityMetadataKeys: config.EntityMetadataKeys,
 	}
 
 	responseData, err := StructToMap(responseObj)
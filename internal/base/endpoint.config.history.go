@@ -0,0 +1,234 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// Path for reading the history of a configuration
+func PathConfigHistory(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "config/history/?" + framework.OptionalParamRegex("path"),
+		Fields: map[string]*framework.FieldSchema{
+			"path": {
+				Type:        framework.TypeString,
+				Description: "The configuration path (e.g: 'config/lease')",
+				Required:    false,
+			},
+		},
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation: b.handleConfigHistoryRead,
+			logical.ListOperation: b.handleConfigHistoryList,
+		},
+
+		HelpSynopsis: "Reads the previous versions of the configurations of this backend",
+		HelpDescription: `This endpoint lists the configurations of this backend with their current version (using 'list'),
+		and returns the last versions of a configuration (using 'read' on 'config/history/<path>'),
+		along with the EntityID that set them.
+
+		The configuration stored before its first change (e.g: the defaults) is version 1.
+		Only the last 10 versions of each configuration are kept, older ones cannot be rolled back to.
+		AccessRequests record the configuration versions they were created under, as 'config_versions'.
+		`,
+	}
+}
+
+// Path for restoring a previous version of a configuration
+func PathConfigRollback(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "config/rollback/" + framework.MatchAllRegex("path"),
+		Fields: map[string]*framework.FieldSchema{
+			"path": {
+				Type:        framework.TypeString,
+				Description: "The configuration path (e.g: 'config/lease')",
+				Required:    true,
+			},
+			"version": {
+				Type:        framework.TypeInt,
+				Description: "The version of the configuration to restore",
+				Required:    true,
+			},
+			ConfigDryRunKey: ConfigDryRunField,
+		},
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.handleConfigRollback,
+		},
+
+		HelpSynopsis: "Restores a previous version of a configuration",
+		HelpDescription: `This endpoint restores the 'version' of the configuration under 'config/rollback/<path>',
+		recording it as a new version. Only the last 10 versions are kept in the history. The restored configuration is validated as on every configuration write.
+
+		The response contains the changed values of the configuration.
+
+		'dry_run' validates the restored configuration and returns the changes, without storing them.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleConfigHistoryList(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	b.ConfigMutex.RLock()
+	defer b.ConfigMutex.RUnlock()
+
+	results := []string{}
+	resultsFull := map[string]interface{}{}
+	for _, section := range b.ConfigSections {
+		version, err := b.ConfigVersionOf(ctx, req.Storage, section.Key)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+		}
+		results = append(results, section.Key)
+		resultsFull[section.Key] = map[string]interface{}{
+			"current_version": version,
+		}
+	}
+
+	return logical.ListResponseWithInfo(results, resultsFull), nil
+}
+
+func (b *BaseBackend) handleConfigHistoryRead(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	path := d.Get("path").(string)
+	if path == "" {
+		return b.handleConfigHistoryList(ctx, req, d)
+	}
+	section, ok := b.configSection(path)
+	if !ok {
+		return ConfigurationErrorResponse(&UnknownConfigKeyError{Key: path})
+	}
+
+	b.ConfigMutex.RLock()
+	defer b.ConfigMutex.RUnlock()
+
+	history, err := b.GetConfigHistoryFromStorage(ctx, req.Storage, path)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+
+	responseObj := responses.ConfigHistoryResponse{
+		Path:           path,
+		CurrentVersion: 1,
+		Versions:       []responses.ConfigVersionResponse{},
+	}
+	if history != nil {
+		responseObj.CurrentVersion = history.CurrentVersion
+		for _, configVersion := range history.Versions {
+			config, err := configVersionFromHistory(history, section, configVersion.Version)
+			if err != nil {
+				return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+			}
+			// Reported as by the configuration endpoint, without its write-only values
+			configResp, err := section.Response(config)
+			if err != nil {
+				return configResp, err
+			}
+			responseObj.Versions = append(responseObj.Versions, responses.ConfigVersionResponse{
+				Version:    configVersion.Version,
+				EntityID:   configVersion.EntityID,
+				CreatedAt:  configVersion.CreatedAt.Unix(),
+				RollbackOf: configVersion.RollbackOf,
+				Config:     configResp.Data,
+			})
+		}
+	}
+
+	responseData, err := StructToMap(responseObj)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+
+	return &logical.Response{Data: responseData}, nil
+}
+
+func (b *BaseBackend) handleConfigRollback(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	path := d.Get("path").(string)
+	version := d.Get("version").(int)
+	dryRun := d.Get(ConfigDryRunKey).(bool)
+
+	section, ok := b.configSection(path)
+	if !ok {
+		return ConfigurationErrorResponse(&UnknownConfigKeyError{Key: path})
+	}
+
+	b.ConfigMutex.Lock()
+	config, diff, proposal, err := b.rollbackConfiguration(ctx, req, section, version, dryRun)
+	b.ConfigMutex.Unlock()
+	if err != nil {
+		return ConfigurationErrorResponse(err)
+	}
+	if proposal != nil {
+		return ConfigProposalResponse(proposal, req.EntityID)
+	}
+
+	b.Logger().Warn("[+] Configuration rolled back",
+		"Path", path,
+		"Version", version,
+		"EntityID", req.EntityID,
+		"DryRun", dryRun,
+	)
+
+	changes := map[string]map[string]responses.ConfigChangeResponse{}
+	warnings := []string{}
+	if len(diff) > 0 {
+		changes[path] = diff
+		if !dryRun {
+			warnings = b.runConfigSectionsApplied(ctx, req, map[string]PluginConfig{path: config})
+		}
+	}
+	return configChangesResponse(!dryRun, changes, warnings)
+}
+
+// rollbackConfiguration restores the 'version' of the configuration of 'section',
+// returning it along with its changes, or the ConfigProposal of restoring it if the configuration is protected.
+// The caller must hold 'ConfigMutex'.
+func (b *BaseBackend) rollbackConfiguration(ctx context.Context, req *logical.Request, section ConfigSection, version int, dryRun bool) (PluginConfig, map[string]responses.ConfigChangeResponse, *ConfigProposal, error) {
+	history, err := b.GetConfigHistoryFromStorage(ctx, req.Storage, section.Key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	config, err := configVersionFromHistory(history, section, version)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := b.validateConfigurationConsistency(ctx, req.Storage, config); err != nil {
+		return nil, nil, nil, err
+	}
+
+	current, err := b.getConfigSectionFromStorage(ctx, req.Storage, section)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	diff := ConfigurationDiff(current, config)
+	if dryRun || len(diff) == 0 {
+		return config, diff, nil, nil
+	}
+
+	protection, err := b.configProtection(ctx, req.Storage)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if protection != nil {
+		proposal, err := b.proposeConfigurations(ctx, req, protection,
+			map[string]PluginConfig{section.Key: config},
+			map[string]map[string]responses.ConfigChangeResponse{section.Key: diff},
+		)
+		return config, diff, proposal, err
+	}
+	return config, diff, nil, b.storeConfigurationVersion(ctx, req.Storage, config, section.Key, req.EntityID, version)
+}
@@ -35,6 +35,7 @@ func PathConfigLease(b *BaseBackend) *framework.Path {
 				Description: "Maximum lease for an Access Requests Claim",
 				Required:    false,
 			},
+			ConfigDryRunKey: ConfigDryRunField,
 		},
 		Callbacks: map[logical.Operation]framework.OperationFunc{
 			logical.UpdateOperation: b.handleConfigLeaseUpdate,
@@ -49,55 +50,66 @@ func PathConfigLease(b *BaseBackend) *framework.Path {
 		'lease_max' configures the maximum duration that an AccessRequest can be claimed for.
 
 		The format for the 'lease' and 'lease_max' is "1h" or integer and then unit.
+
+		'lease' cannot be longer than 'lease_max', and 'lease_max' cannot exceed
+		the 'delete_after' of '/config' (minus its 'request_ttl').
+
+		'dry_run' validates the configuration and returns it, without storing it.
 		`,
 	}
 }
 
+// Section of '/config/bundle' for the lease configuration
+func ConfigSectionConfigLease(b *BaseBackend) ConfigSection {
+	return ConfigSection{
+		Key:    ConfigLeaseKey,
+		Fields: PathConfigLease(b).Fields,
+		Default: func() PluginConfig {
+			configLease := NewConfigLease()
+			return &configLease
+		},
+		Response: func(config PluginConfig) (*logical.Response, error) {
+			return configLeaseResponse(config.(*ConfigLease))
+		},
+	}
+}
+
 func (b *BaseBackend) handleConfigLeaseUpdate(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
-	entityID := req.EntityID
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	b.ConfigMutex.Lock()
+	defer b.ConfigMutex.Unlock()
 
 	config, err := GetConfiguration[*ConfigLease](ctx, b, req, "")
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
-	for key := range d.Raw {
-		value, ok := d.GetOk(key)
-		if !ok {
-			continue
-		}
-		b.Logger().Info("[*] Replacing configuration value",
-			"EntityID", entityID,
-			"ConfigKey", key,
-			// "OldValue", config
-			"NewValue", value,
-		)
-
-		err := config.SetConfigurationKey(key, value)
-		if err != nil {
-			return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrPermissionDenied
-		}
-	}
-
-	err = StoreConfiguration[*ConfigLease](ctx, b, req, config, "")
+	proposal, stored, err := UpdateConfiguration(ctx, b, req, d, config, "")
 	if err != nil {
-		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+		return ConfigurationErrorResponse(err)
+	}
+	if proposal != nil {
+		return ConfigProposalResponse(proposal, req.EntityID)
+	}
+	if !stored {
+		return configLeaseResponse(config)
 	}
 
 	return &logical.Response{}, nil
 }
 
 func (b *BaseBackend) handleConfigLeaseRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	b.ConfigMutex.RLock()
+	defer b.ConfigMutex.RUnlock()
 
 	config, err := GetConfiguration[*ConfigLease](ctx, b, req, "")
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
+	return configLeaseResponse(config)
+}
+
+func configLeaseResponse(config *ConfigLease) (*logical.Response, error) {
 	responseObj := responses.ConfigLeaseResponse{
 		Lease:    config.Lease.Seconds(),
 		LeaseMax: config.LeaseMax.Seconds(),
@@ -0,0 +1,291 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// Path for listing, reading and withdrawing configuration changes awaiting approval
+func PathConfigProposal(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "config/proposal(/" + framework.GenericNameRegex("id") + ")?/?",
+		Fields: map[string]*framework.FieldSchema{
+			"id": {
+				Type:        framework.TypeString,
+				Description: "The ID of the ConfigProposal",
+				Required:    false,
+			},
+		},
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation:   b.handleConfigProposalList,
+			logical.ReadOperation:   b.handleConfigProposalRead,
+			logical.DeleteOperation: b.handleConfigProposalDelete,
+		},
+
+		HelpSynopsis: "Lists the configuration changes awaiting approval",
+		HelpDescription: `When 'protect_config' is set under '/config', writes to the configuration endpoints
+		are not stored, but create ConfigProposals that need 'config_required_approvals' approvals.
+
+		This endpoint lists the ConfigProposals with their changes (using 'list'),
+		returns a ConfigProposal (using 'read' on 'config/proposal/<id>')
+		and withdraws it (using 'delete' on 'config/proposal/<id>').
+		Only the owner of a ConfigProposal can withdraw it, which is recorded in 'withdrawn_by'.
+
+		ConfigProposals expire after 'request_ttl' and are deleted after 'delete_after'.
+		`,
+	}
+}
+
+// Path for approving configuration changes
+func PathConfigProposalApprove(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "config/proposal/" + framework.GenericNameRegex("id") + "/approve/?",
+		Fields: map[string]*framework.FieldSchema{
+			"id": {
+				Type:        framework.TypeString,
+				Description: "The ID of the ConfigProposal to approve",
+				Required:    true,
+			},
+		},
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.UpdateOperation: b.handleConfigProposalApprove,
+		},
+
+		HelpSynopsis: "Approves a configuration change",
+		HelpDescription: `This endpoint approves the ConfigProposal 'id'.
+		Entities cannot approve their own ConfigProposals.
+
+		Once approved by 'config_required_approvals' Entities, the configuration change is stored.
+		If the changed configurations were written since the ConfigProposal was created,
+		it is 'rejected' instead and has to be proposed again.
+
+		Only the Entities listed in 'config_approvers' (under '/config'), or members of the identity groups
+		listed in it, can approve, in addition to being allowed to 'update' this endpoint by ACL policy.
+		They are distinct from the approvers of AccessRequests.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleConfigProposalList(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	b.ConfigMutex.RLock()
+	proposals, err := b.ListConfigProposals(ctx, req.Storage)
+	b.ConfigMutex.RUnlock()
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].CreatedAt.Before(proposals[j].CreatedAt)
+	})
+
+	results := []string{}
+	resultsFull := map[string]interface{}{}
+	for _, proposal := range proposals {
+		results = append(results, proposal.ID)
+
+		responseData, err := StructToMap(newConfigProposalResponse(proposal, req.EntityID))
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprint(err)), nil
+		}
+		resultsFull[proposal.ID] = responseData
+	}
+
+	return logical.ListResponseWithInfo(
+		results,
+		resultsFull, // for the 'vault list -detailed path/' command
+	), nil
+}
+
+func (b *BaseBackend) handleConfigProposalRead(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	proposalID := d.Get("id").(string)
+	if proposalID == "" {
+		return b.handleConfigProposalList(ctx, req, d)
+	}
+
+	b.ConfigMutex.RLock()
+	proposal, err := b.GetConfigProposalFromStorage(ctx, req.Storage, proposalID)
+	b.ConfigMutex.RUnlock()
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	if proposal == nil {
+		return &logical.Response{Warnings: []string{"ConfigProposal does not exist"}}, nil
+	}
+
+	responseData, err := StructToMap(newConfigProposalResponse(proposal, req.EntityID))
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	return &logical.Response{Data: responseData}, nil
+}
+
+func (b *BaseBackend) handleConfigProposalDelete(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	entityID := req.EntityID
+	if entityID == "" {
+		return logical.ErrorResponse("Token has no EntityID assigned"), logical.ErrPermissionDenied
+	}
+	proposalID := d.Get("id").(string)
+	if proposalID == "" {
+		return logical.ErrorResponse("'id' is required"), logical.ErrInvalidRequest
+	}
+
+	b.ConfigMutex.Lock()
+	defer b.ConfigMutex.Unlock()
+
+	proposal, err := b.GetConfigProposalFromStorage(ctx, req.Storage, proposalID)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	if proposal == nil {
+		return &logical.Response{Warnings: []string{"ConfigProposal does not exist"}}, nil
+	}
+
+	if proposal.OwnerID != entityID {
+		return logical.ErrorResponse("Only the owner of a ConfigProposal can withdraw it"), logical.ErrPermissionDenied
+	}
+	if err := proposal.Withdraw(entityID); err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrInvalidRequest
+	}
+	// Kept until 'delete_after', so that the withdrawal is recorded
+	if err := b.storeConfigProposalToStorage(ctx, req.Storage, proposal); err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	b.Logger().Info("[+] ConfigProposal withdrawn",
+		"ProposalID", proposalID,
+		"EntityID", entityID,
+	)
+
+	responseData, err := StructToMap(newConfigProposalResponse(proposal, entityID))
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	return &logical.Response{Data: responseData}, nil
+}
+
+func (b *BaseBackend) handleConfigProposalApprove(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	entityID := req.EntityID
+	if entityID == "" {
+		return logical.ErrorResponse("Token has no EntityID assigned"), logical.ErrPermissionDenied
+	}
+	proposalID := d.Get("id").(string)
+
+	b.ConfigMutex.Lock()
+	proposal, err := b.GetConfigProposalFromStorage(ctx, req.Storage, proposalID)
+	if err != nil {
+		b.ConfigMutex.Unlock()
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	if proposal == nil {
+		b.ConfigMutex.Unlock()
+		return &logical.Response{Warnings: []string{"ConfigProposal does not exist"}}, nil
+	}
+
+	// The approvers are the ones currently configured, as changing them requires approval
+	config, err := GetConfigurationFromStorage[*Config](ctx, b, req.Storage, ConfigKey)
+	if err != nil {
+		b.ConfigMutex.Unlock()
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	approver, err := b.isConfigApprover(config, entityID)
+	if err != nil {
+		b.ConfigMutex.Unlock()
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	if !approver {
+		b.ConfigMutex.Unlock()
+		return logical.ErrorResponse("Entity is not one of the 'config_approvers'"), logical.ErrPermissionDenied
+	}
+
+	if err := proposal.Approve(entityID); err != nil {
+		b.ConfigMutex.Unlock()
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrPermissionDenied
+	}
+	b.Logger().Info("[+] ConfigProposal approved",
+		"ProposalID", proposal.ID,
+		"ApproverID", entityID,
+		"Approvals", len(proposal.Approvals),
+		"RequiredApprovals", proposal.RequiredApprovals,
+	)
+
+	var applied map[string]PluginConfig
+	if proposal.isApproved() {
+		applied, err = b.applyConfigProposal(ctx, req.Storage, proposal)
+	} else {
+		err = b.storeConfigProposalToStorage(ctx, req.Storage, proposal)
+	}
+	b.ConfigMutex.Unlock()
+
+	if err != nil && proposal.Status == models.Rejected {
+		return logical.ErrorResponse(fmt.Sprintf("The ConfigProposal was rejected: %s", err)), logical.ErrInvalidRequest
+	}
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+
+	responseData, err := StructToMap(newConfigProposalResponse(proposal, entityID))
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	// Reload what depends on the configurations outside of the configuration lock
+	warnings := b.runConfigSectionsApplied(ctx, req, applied)
+	return &logical.Response{Data: responseData, Warnings: warnings}, nil
+}
+
+// ConfigProposalResponse returns the response of a configuration write that created 'proposal'
+func ConfigProposalResponse(proposal *ConfigProposal, entityID string) (*logical.Response, error) {
+	responseData, err := StructToMap(newConfigProposalResponse(proposal, entityID))
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+
+	return &logical.Response{
+		Data: responseData,
+		Warnings: []string{fmt.Sprintf(
+			"The configuration is protected, the change awaits %d approvals as ConfigProposal '%s'",
+			proposal.RequiredApprovals, proposal.ID,
+		)},
+	}, nil
+}
+
+func newConfigProposalResponse(proposal *ConfigProposal, entityID string) responses.ConfigProposalResponse {
+	_, haveApproved := proposal.Approvals[entityID]
+	var withdrawnAt int64
+	if proposal.WithdrawnBy != "" {
+		withdrawnAt = proposal.WithdrawnAt.Unix()
+	}
+	return responses.ConfigProposalResponse{
+		ID:      proposal.ID,
+		OwnerID: proposal.OwnerID,
+
+		CreatedAt:  proposal.CreatedAt.Unix(),
+		Expiration: proposal.Expiration.Unix(),
+		Deletion:   proposal.Deletion.Unix(),
+
+		Status:            proposal.Status,
+		RequiredApprovals: proposal.RequiredApprovals,
+		NumOfApprovals:    len(proposal.Approvals),
+		HaveApproved:      haveApproved,
+
+		WithdrawnBy: proposal.WithdrawnBy,
+		WithdrawnAt: withdrawnAt,
+
+		Diff:         proposal.Diff,
+		BaseVersions: proposal.BaseVersions,
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+var freezeReasonField = &framework.FieldSchema{
+	Type:        framework.TypeString,
+	Description: "Why the freeze state is changed (recorded with the caller)",
+	Required:    true,
+}
+
+var freezeMessageField = &framework.FieldSchema{
+	Type:        framework.TypeString,
+	Description: "The message returned by the blocked endpoints while frozen",
+	Required:    false,
+}
+
+// Path for freezing the Gate
+func PathFreeze(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: FreezeKey,
+		Fields: map[string]*framework.FieldSchema{
+			"reason":  freezeReasonField,
+			"message": freezeMessageField,
+		},
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleFreezeUpdate,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: b.handleFreezeRead,
+			},
+		},
+		HelpSynopsis: "Freezes the Gate, blocking new access",
+		HelpDescription: `This endpoint freezes the Gate (using 'update'), and returns its freeze state (using 'read').
+
+		While frozen, '/request', '/approve' and '/claim' return 'message',
+		while the claims already active keep their access (see '/kill').
+
+		Freezing, killing and unfreezing are recorded with the caller and 'reason'.
+		The Gate is unfrozen using '/unfreeze'.
+		`,
+	}
+}
+
+// Path for unfreezing the Gate
+func PathUnfreeze(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "unfreeze",
+		Fields: map[string]*framework.FieldSchema{
+			"reason": freezeReasonField,
+		},
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleUnfreeze,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+		},
+		HelpSynopsis: "Unfreezes the Gate",
+		HelpDescription: `This endpoint unfreezes the Gate frozen by '/freeze' or '/kill',
+		allowing '/request', '/approve' and '/claim' again.
+
+		Claims revoked while frozen remain revoked.
+		`,
+	}
+}
+
+// Path for freezing the Gate and revoking all active claims
+func PathKill(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "kill",
+		Fields: map[string]*framework.FieldSchema{
+			"reason":  freezeReasonField,
+			"message": freezeMessageField,
+		},
+		Operations: map[logical.Operation]framework.OperationHandler{
+			// Revocation runs the Remove hook, which reaches the external API
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleKill,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+		},
+		HelpSynopsis: "Freezes the Gate and revokes all active claims",
+		HelpDescription: `This endpoint freezes the Gate (as '/freeze', if not frozen already),
+		and revokes every 'active' claim as '/revoke' does, with the 'kill' revocation reason.
+
+		The response lists the revoked claims, the ones whose removal is retried
+		under '/revocations/pending' and the ones that could not be revoked.
+		`,
+	}
+}
+
+// freezeReason returns the required 'reason' of a freeze state change
+func freezeReason(d *framework.FieldData) (string, error) {
+	reason := strings.TrimSpace(d.Get("reason").(string))
+	if reason == "" {
+		return "", fmt.Errorf("A 'reason' is required to change the freeze state")
+	}
+	return reason, nil
+}
+
+func (b *BaseBackend) handleFreezeRead(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	freeze, err := b.GetFreezeFromStorage(ctx, req.Storage)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	return freezeResponse(freeze)
+}
+
+func (b *BaseBackend) handleFreezeUpdate(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	reason, err := freezeReason(d)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrInvalidRequest
+	}
+	freeze, err := b.SetFrozen(ctx, req.Storage, true, revocationActor(req), reason, d.Get("message").(string))
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	return freezeResponse(freeze)
+}
+
+func (b *BaseBackend) handleUnfreeze(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	reason, err := freezeReason(d)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrInvalidRequest
+	}
+	freeze, err := b.SetFrozen(ctx, req.Storage, false, revocationActor(req), reason, "")
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	return freezeResponse(freeze)
+}
+
+func (b *BaseBackend) handleKill(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	reason, err := freezeReason(d)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrInvalidRequest
+	}
+	actor := revocationActor(req)
+
+	freeze, err := b.GetFreezeFromStorage(ctx, req.Storage)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	// No claim must start while the active ones are revoked
+	if !freeze.Frozen {
+		if _, err := b.SetFrozen(ctx, req.Storage, true, actor, reason, d.Get("message").(string)); err != nil {
+			return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+		}
+	}
+
+	report, err := b.KillClaims(ctx, req, actor, reason)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	freeze, err = b.GetFreezeFromStorage(ctx, req.Storage)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+
+	responseData, err := StructToMap(responses.KillResponse{
+		FreezeResponse: newFreezeResponse(freeze),
+		Revoked:        report.Revoked,
+		Queued:         report.Queued,
+		Failed:         report.Failed,
+	})
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	resp := &logical.Response{Data: responseData}
+	if len(report.Failed) > 0 {
+		resp.AddWarning(fmt.Sprintf("%d active claims could not be revoked", len(report.Failed)))
+	}
+	return resp, nil
+}
+
+func freezeResponse(freeze *Freeze) (*logical.Response, error) {
+	responseData, err := StructToMap(newFreezeResponse(freeze))
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	return &logical.Response{Data: responseData}, nil
+}
+
+func newFreezeResponse(freeze *Freeze) responses.FreezeResponse {
+	responseObj := responses.FreezeResponse{
+		Frozen:  freeze.Frozen,
+		Message: freeze.Message,
+		Events:  []responses.FreezeEventResponse{},
+	}
+	for _, event := range freeze.Events {
+		responseObj.Events = append(responseObj.Events, responses.FreezeEventResponse{
+			Action:    event.Action,
+			Actor:     event.Actor,
+			Reason:    event.Reason,
+			CreatedAt: event.CreatedAt.Unix(),
+			Revoked:   event.Revoked,
+		})
+	}
+	return responseObj
+}
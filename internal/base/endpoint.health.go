@@ -0,0 +1,140 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+const (
+	HealthCheckPassed = "ok"
+	HealthCheckFailed = "failed"
+)
+
+// HealthCheck is a check reported by '/health' (e.g: the authentication to an API)
+type HealthCheck struct {
+	Name string
+	// Returns a message describing the passed check, or why it failed
+	Check func(ctx context.Context, storage logical.Storage) (string, error)
+}
+
+// Path for checking the connectivity and permissions the backend depends on
+func PathHealth(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "health",
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation: b.handleHealthRead,
+		},
+		HelpSynopsis: "Checks the configuration, connectivity and permissions of this backend",
+		HelpDescription: `This endpoint runs the checks of this backend (e.g: the configurations can be read,
+		the Gate can authenticate to its API and has the capabilities required to grant access)
+		and reports the status ('ok' or 'failed') and a message for each of them.
+
+		'healthy' is set if all checks passed. Failed checks are also logged.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleHealthRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	responseObj := responses.HealthResponse{
+		Healthy: true,
+		Checks:  []responses.HealthCheckResponse{},
+	}
+
+	for _, check := range b.HealthChecks {
+		checkResponse := responses.HealthCheckResponse{
+			Name:   check.Name,
+			Status: HealthCheckPassed,
+		}
+		message, err := check.Check(ctx, req.Storage)
+		if err != nil {
+			b.Logger().Warn("[-] Health check failed",
+				"Check", check.Name,
+				"error", err,
+			)
+			responseObj.Healthy = false
+			checkResponse.Status = HealthCheckFailed
+			message = fmt.Sprint(err)
+		}
+		checkResponse.Message = message
+		responseObj.Checks = append(responseObj.Checks, checkResponse)
+	}
+
+	responseData, err := StructToMap(responseObj)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+
+	return &logical.Response{Data: responseData}, nil
+}
+
+// Health check of the stored plugin configurations
+func HealthCheckConfig(b *BaseBackend) HealthCheck {
+	return HealthCheck{
+		Name: "config",
+		Check: func(ctx context.Context, storage logical.Storage) (string, error) {
+			b.ConfigMutex.RLock()
+			defer b.ConfigMutex.RUnlock()
+
+			for _, section := range b.ConfigSections {
+				config, err := b.getConfigSectionFromStorage(ctx, storage, section)
+				if err != nil {
+					return "", err
+				}
+				if err := config.Validate(); err != nil {
+					return "", fmt.Errorf("'%s': %w", section.Key, err)
+				}
+			}
+			config, err := GetConfigurationFromStorage[*Config](ctx, b, storage, ConfigKey)
+			if err != nil {
+				return "", err
+			}
+			configLease, err := GetConfigurationFromStorage[*ConfigLease](ctx, b, storage, ConfigLeaseKey)
+			if err != nil {
+				return "", err
+			}
+			if err := ValidateConfigurationConsistency(config, configLease); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d configurations are valid", len(b.ConfigSections)), nil
+		},
+	}
+}
+
+// Health check of the key encrypting AccessRequests, if 'encrypt_requests' is set
+func HealthCheckRequestKeyring(b *BaseBackend) HealthCheck {
+	return HealthCheck{
+		Name: "request_keyring",
+		Check: func(ctx context.Context, storage logical.Storage) (string, error) {
+			b.ConfigMutex.RLock()
+			config, err := GetConfigurationFromStorage[*Config](ctx, b, storage, ConfigKey)
+			b.ConfigMutex.RUnlock()
+			if err != nil {
+				return "", err
+			}
+			if !config.EncryptRequests {
+				return "AccessRequests are not encrypted", nil
+			}
+
+			keyring, err := b.getRequestKeyring(ctx, storage)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("AccessRequests are encrypted with key version %d", keyring.CurrentVersion), nil
+		},
+	}
+}
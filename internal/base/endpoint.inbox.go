@@ -0,0 +1,89 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// Path for approvers to list the AccessRequests awaiting their approval
+func PathInbox(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "inbox/?",
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.handleInboxList,
+		},
+		HelpSynopsis: "Lists the AccessRequests awaiting the caller's approval",
+		HelpDescription: `This endpoint lists the 'pending' AccessRequests that the caller
+		did not create and has not approved yet, oldest first.
+
+		'age' is the number of seconds since the AccessRequest was created
+		and 'time_remaining' the number of seconds until it expires.
+
+		Which entities can approve is governed by the Vault/OpenBao policies on the '/approve' endpoint.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleInboxList(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	entityID := req.EntityID
+
+	if entityID == "" {
+		return logical.ErrorResponse("Token has no EntityID assigned"), logical.ErrPermissionDenied
+	}
+
+	pending := models.Pending
+	accessRequests, _, err := b.ListRequests(ctx, req, RequestFilter{
+		Status: &pending,
+		SortBy: SortByCreation,
+	})
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+
+	resultsFull := map[string]interface{}{}
+	results := []string{}
+
+	now := time.Now()
+	entities := b.newEntityResolver(ctx, req)
+	for _, accessRequest := range accessRequests {
+		if accessRequest.OwnerID == entityID || accessRequest.isApprovedBy(entityID) {
+			continue
+		}
+		results = append(results, accessRequest.OwnerID)
+
+		responseObj := responses.AccessRequestInboxResponse{
+			AccessRequestResponse: newAccessRequestResponse(accessRequest, entityID, entities),
+
+			Age:           int64(now.Sub(accessRequest.CreatedAt) / time.Second),
+			TimeRemaining: int64(accessRequest.Expiration.Sub(now) / time.Second),
+		}
+
+		responseData, err := StructToMap(responseObj)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprint(err)), nil
+		}
+		resultsFull[accessRequest.OwnerID] = responseData
+	}
+
+	return logical.ListResponseWithInfo(
+		results,
+		resultsFull,
+	), nil
+}
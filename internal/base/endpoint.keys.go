@@ -0,0 +1,94 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// Path for reporting the versions of the key encrypting AccessRequests in storage
+func PathRequestKeys(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: RequestKeyringKey,
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation: b.handleRequestKeysRead,
+		},
+		HelpSynopsis: "Reports the versions of the key encrypting AccessRequests",
+		HelpDescription: `This endpoint reports the versions of the plugin-managed key
+		that encrypts AccessRequests when 'encrypt_requests' is set under '/config'.
+
+		The key material is never returned.
+		`,
+	}
+}
+
+// Path for rotating the key encrypting AccessRequests in storage
+func PathRequestKeysRotate(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: RequestKeyringKey + "/rotate",
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleRequestKeysRotate,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+		},
+		HelpSynopsis: "Rotates the key encrypting AccessRequests",
+		HelpDescription: `This endpoint creates a new version of the key encrypting AccessRequests.
+
+		New writes use the new version, while existing AccessRequests
+		are re-encrypted by the periodic tidy.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleRequestKeysRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	keyring, err := b.getRequestKeyring(ctx, req.Storage)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	return requestKeyringResponse(keyring)
+}
+
+func (b *BaseBackend) handleRequestKeysRotate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	keyring, err := b.RotateRequestKey(ctx, req.Storage)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	b.Logger().Warn("[+] Rotated the AccessRequest encryption key",
+		"EntityID", req.EntityID,
+		"KeyVersion", keyring.CurrentVersion,
+	)
+	return requestKeyringResponse(keyring)
+}
+
+func requestKeyringResponse(keyring *RequestKeyring) (*logical.Response, error) {
+	responseObj := responses.RequestKeyringResponse{
+		CurrentVersion: keyring.CurrentVersion,
+		Versions:       map[int]int64{},
+	}
+	for version, key := range keyring.Keys {
+		responseObj.Versions[version] = key.CreatedAt.Unix()
+	}
+
+	responseData, err := StructToMap(responseObj)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+
+	return &logical.Response{Data: responseData}, nil
+}
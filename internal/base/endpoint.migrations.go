@@ -0,0 +1,70 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// Path for storage migration status
+func PathMigrations(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: MigrationsKey,
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation: b.handleMigrationsRead,
+		},
+
+		HelpSynopsis: "Reports the storage schema migrations of this backend",
+		HelpDescription: `This endpoint reports the schema version of the stored AccessRequests and configurations.
+
+		Migrations to the latest schema version run when the plugin is initialized.
+		'records' lists every migration run, along with its error if it failed.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleMigrationsRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	status, err := b.GetMigrationStatusFromStorage(ctx, req.Storage)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+
+	records := make([]responses.MigrationRecordResponse, 0, len(status.Records))
+	for _, record := range status.Records {
+		records = append(records, responses.MigrationRecordResponse{
+			Version:     record.Version,
+			Description: record.Description,
+			AppliedAt:   record.AppliedAt.Unix(),
+			Error:       record.Error,
+		})
+	}
+
+	responseObj := responses.MigrationStatusResponse{
+		SchemaVersion:       status.SchemaVersion,
+		LatestSchemaVersion: LatestSchemaVersion(),
+		UpToDate:            status.SchemaVersion >= LatestSchemaVersion(),
+		LastRun:             status.LastRun.Unix(),
+		Records:             records,
+	}
+
+	responseData, err := StructToMap(responseObj)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+
+	return &logical.Response{Data: responseData}, nil
+}
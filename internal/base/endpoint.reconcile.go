@@ -0,0 +1,93 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// Path for reconciling claims with the access in place
+func PathReconcile(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: ReconcileKey,
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleReconcileUpdate,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: b.handleReconcileRead,
+			},
+		},
+		HelpSynopsis: "Reconciles the claims of this backend with the access in place",
+		HelpDescription: `This endpoint compares the claims of active, revoked and expired AccessRequests
+		with the access the Gate finds in place (using 'update'), and returns the last report (using 'read').
+
+		Access left in place after a claim was revoked or expired is removed again.
+		Revoked and expired claims are checked until their access is found removed once,
+		so that access assigned to the requestor later is not removed.
+		Active claims whose lease was not revoked after their TTL are removed and set as 'expired',
+		and active claims missing their access are reported.
+
+		Reconciliation also runs periodically, every 'reconcile_interval' (under '/config').
+		`,
+	}
+}
+
+func (b *BaseBackend) handleReconcileUpdate(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	report, err := b.Reconcile(ctx, req)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	return reconcileReportResponse(report)
+}
+
+func (b *BaseBackend) handleReconcileRead(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	report, err := b.GetReconcileReportFromStorage(ctx, req.Storage)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	if report == nil {
+		return &logical.Response{Warnings: []string{"Claims have not been reconciled yet"}}, nil
+	}
+	return reconcileReportResponse(report)
+}
+
+func reconcileReportResponse(report *ReconcileReport) (*logical.Response, error) {
+	responseObj := responses.ReconcileReportResponse{
+		RunAt:         report.RunAt.Unix(),
+		Checked:       report.Checked,
+		Discrepancies: []responses.ReconcileDiscrepancyResponse{},
+	}
+	for _, discrepancy := range report.Discrepancies {
+		responseObj.Discrepancies = append(responseObj.Discrepancies, responses.ReconcileDiscrepancyResponse{
+			RequestorID: discrepancy.RequestorID,
+			Status:      discrepancy.Status,
+			Grant:       discrepancy.Grant,
+			Action:      discrepancy.Action,
+			Error:       discrepancy.Error,
+		})
+	}
+
+	responseData, err := StructToMap(responseObj)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+
+	return &logical.Response{Data: responseData}, nil
+}
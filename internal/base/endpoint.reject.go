@@ -0,0 +1,102 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func PathReject(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "reject/(?P<requestor_id>[^/]+)/?",
+		Fields: map[string]*framework.FieldSchema{
+			"requestor_id": {
+				Type:        framework.TypeString,
+				Description: "The RequestorID of the AccessRequest to reject",
+				Required:    true,
+			},
+			"reason": {
+				Type:        framework.TypeString,
+				Description: "Why the AccessRequest is rejected",
+				Required:    false,
+			},
+		},
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleReject,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+		},
+		HelpSynopsis: "Rejects the AccessRequest created by the provided RequestorID",
+		HelpDescription: `This endpoint rejects AccessRequests that are 'pending' or 'approved' (not claimed yet).
+
+		'requestor_id' designates the owner of the AccessRequest to be rejected,
+		and 'reason' is reported on the AccessRequest.
+
+		Which entities can reject is governed by the Vault/OpenBao policies on this endpoint.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleReject(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	if req.EntityID == "" {
+		return logical.ErrorResponse("Token has no EntityID assigned"), logical.ErrPermissionDenied
+	}
+
+	requestorID := d.Get("requestor_id").(string)
+	reason := strings.TrimSpace(d.Get("reason").(string))
+	return b.rejectRequest(ctx, req, requestorID, reason)
+}
+
+// rejectRequest rejects the AccessRequest of 'requestorID' by the caller (also used by '/reject' in bulk)
+func (b *BaseBackend) rejectRequest(ctx context.Context, req *logical.Request, requestorID string, reason string) (*logical.Response, error) {
+	rejecterID := req.EntityID
+
+	lock := b.RequestLock(requestorID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if requestorID == rejecterID {
+		return logical.ErrorResponse("Entities cannot reject their own requests"), logical.ErrPermissionDenied
+	}
+
+	accessRequest, err := b.GetRequest(ctx, req, requestorID)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	if accessRequest == nil {
+		return &logical.Response{Warnings: []string{"Request does not exist"}}, nil
+	}
+
+	if err := accessRequest.Reject(rejecterID, reason); err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	if err := b.StoreRequest(ctx, req, accessRequest); err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	b.Logger().Info("[+] AccessRequest rejected",
+		"RequestorID", requestorID,
+		"RejecterID", rejecterID,
+		"Reason", reason,
+	)
+
+	responseData, err := StructToMap(newAccessRequestResponse(*accessRequest, rejecterID, b.newEntityResolver(ctx, req)))
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	return &logical.Response{Data: responseData}, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// Path for requestors to release their claim before its lease ends
+func PathRelease(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "release",
+		Fields: map[string]*framework.FieldSchema{
+			"reason": {
+				Type:        framework.TypeString,
+				Description: "Why the claim is released",
+				Required:    false,
+			},
+		},
+		Operations: map[logical.Operation]framework.OperationHandler{
+			// Release runs the Remove hook, which reaches the external API
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleRelease,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+		},
+		HelpSynopsis: "Releases the active claim of the caller",
+		HelpDescription: `This endpoint removes the access of the caller's 'active' AccessRequest
+		and sets it as 'revoked', recording 'released' as the revocation reason, the caller and 'reason'.
+
+		The lease of the claim is not revoked by the Gate (see '/revoke'), it can be revoked
+		by the caller using 'sys/leases/revoke' and its 'lease_id'.
+		If the access cannot be removed, it is retried under '/revocations/pending'.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleRelease(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	requestorID := req.EntityID
+	if requestorID == "" {
+		return logical.ErrorResponse("Token has no EntityID assigned"), logical.ErrPermissionDenied
+	}
+	reason := strings.TrimSpace(d.Get("reason").(string))
+
+	lock := b.RequestLock(requestorID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	accessRequest, err := b.GetRequest(ctx, req, requestorID)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	if accessRequest == nil {
+		return &logical.Response{Warnings: []string{"Request does not exist"}}, nil
+	}
+
+	queued, err := b.revokeClaim(ctx, req, accessRequest, RevocationReasonReleased, requestorID, reason)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrInvalidRequest
+	}
+
+	responseData, err := StructToMap(newAccessRequestResponse(*accessRequest, req.EntityID, b.newEntityResolver(ctx, req)))
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	resp := &logical.Response{Data: responseData}
+	if queued {
+		resp.AddWarning("The access could not be removed, its removal is retried under '/revocations/pending'")
+	}
+	return resp, nil
+}
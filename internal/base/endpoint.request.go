@@ -39,11 +39,59 @@ func PathRequest(b *BaseBackend) *framework.Path {
 				Description: "Duration of the requested access",
 				Required:    false,
 			},
+			// Listing filters
+			"status": {
+				Type:        framework.TypeLowerCaseString,
+				Description: "List only AccessRequests in this status (e.g: 'pending')",
+				Required:    false,
+			},
+			"requestor": {
+				Type:        framework.TypeString,
+				Description: "List only the AccessRequest of this RequestorID",
+				Required:    false,
+			},
+			"approved_by_me": {
+				Type:        framework.TypeBool,
+				Description: "List only AccessRequests approved by the caller",
+				Required:    false,
+			},
+			"created_after": {
+				Type:        framework.TypeTime,
+				Description: "List only AccessRequests created after this time (RFC3339 or Unix time)",
+				Required:    false,
+			},
+			// Listing order and pagination
+			"sort_by": {
+				Type:          framework.TypeLowerCaseString,
+				Description:   "Order of the listed AccessRequests ('requestor_id' or 'iat')",
+				Required:      false,
+				Default:       SortByRequestorID,
+				AllowedValues: []interface{}{SortByRequestorID, SortByCreation},
+			},
+			"after": {
+				Type:        framework.TypeString,
+				Description: "List AccessRequests after this cursor (the 'next' value of the previous page)",
+				Required:    false,
+			},
+			"limit": {
+				Type:        framework.TypeInt,
+				Description: "Maximum number of listed AccessRequests (0 lists all)",
+				Required:    false,
+			},
 		},
-		Callbacks: map[logical.Operation]framework.OperationFunc{
-			logical.UpdateOperation: b.handleRequestUpdate,
-			logical.ListOperation:   b.handleRequestList,
-			logical.ReadOperation:   b.handleRequestRead,
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleRequestUpdate,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+			// Reads do not write to storage and are served locally
+			logical.ListOperation: &framework.PathOperation{
+				Callback: b.handleRequestList,
+			},
+			logical.ReadOperation: &framework.PathOperation{
+				Callback: b.handleRequestRead,
+			},
 		},
 
 		DisplayAttrs: &framework.DisplayAttributes{
@@ -56,10 +104,19 @@ func PathRequest(b *BaseBackend) *framework.Path {
 		check the AccessRequest created by the requestor (using 'read')
 		and list all AccessRequests created by this backend (using 'list').
 
+		Listing can be filtered by 'status', 'requestor', 'approved_by_me' and 'created_after',
+		ordered by 'sort_by' and paginated by 'limit'. The 'next' value of a page
+		is passed as 'after' to retrieve the following one. It is the RequestorID of the last listed
+		AccessRequest, or its creation time and RequestorID when sorting by 'iat', so pages resume
+		at the same position even if that AccessRequest changes status, is deleted or is re-created.
+
 		The 'justification' parameter can be mandatory if 'require_justification' is set under '/config' endpoint.
 
 		The 'ttl' parameter is the duration that the requested access will be in effect
 		and must be between 'lease' and 'lease_max', inclusive.
+
+		AccessRequests report the access configuration of the Gate at their creation as 'access',
+		which is what gets approved and granted on claim.
 		`,
 	}
 }
@@ -71,12 +128,16 @@ func (b *BaseBackend) handleRequestUpdate(ctx context.Context, req *logical.Requ
 	}
 
 	justification := d.Get("justification").(string)
-	// TypeDurationSecond returns an integer number of seconds. ClaimTTL retains
-	// that representation for API and persisted-storage compatibility.
-	ttlSeconds := time.Duration(d.Get("ttl").(int))
+	// TypeDurationSecond returns an integer number of seconds
+	ttl := time.Duration(d.Get("ttl").(int)) * time.Second
+
+	lock := b.RequestLock(entityID)
+	lock.Lock()
+	defer lock.Unlock()
 
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	if resp, err := b.frozenResponse(ctx, req.Storage); resp != nil {
+		return resp, err
+	}
 
 	existingRequest, err := b.GetRequest(ctx, req, entityID)
 	if err != nil {
@@ -87,32 +148,57 @@ func (b *BaseBackend) handleRequestUpdate(ctx context.Context, req *logical.Requ
 	}
 	overwrite := existingRequest != nil
 
+	b.ConfigMutex.RLock()
 	config, err := GetConfiguration[*Config](ctx, b, req, ConfigKey)
 	if err != nil {
+		b.ConfigMutex.RUnlock()
 		return logical.ErrorResponse(fmt.Sprint(err)), nil
 	}
 
 	configLease, err := GetConfiguration[*ConfigLease](ctx, b, req, ConfigLeaseKey)
 	if err != nil {
+		b.ConfigMutex.RUnlock()
 		return logical.ErrorResponse(fmt.Sprint(err)), nil
 	}
 
+	configVersions, err := b.currentConfigVersions(ctx, req.Storage)
+	if err != nil {
+		b.ConfigMutex.RUnlock()
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+
+	access, err := b.accessSnapshot(ctx, req.Storage)
+	b.ConfigMutex.RUnlock()
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+
 	b.Logger().Info("[+] Access Requested",
 		"EntityID", entityID,
 		"PreviousRequestExistence", overwrite,
-		"Justification", justification,
 		"JustificationRequired", config.RequireJustification,
 		"JustificationNoWhitspaceLength", len(strings.TrimSpace(justification)),
-		"ClaimTTLSeconds", ttlSeconds,
+		"ClaimTTL", ttl,
 	)
 
-	accessRequest, err := NewAccessRequest(config, configLease, entityID, ttlSeconds, justification)
+	accessRequest, err := NewAccessRequest(config, configLease, entityID, ttl, justification)
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrPermissionDenied
 	}
+	accessRequest.ConfigVersions = configVersions
+	accessRequest.Access = access
 
+	// Continue the version of the replaced AccessRequest,
+	// which may still be in storage even if it is past its deletion time
+	accessRequest.Version, err = b.getRequestVersionFromStorage(ctx, req.Storage, entityID)
+	if err != nil {
+		return logical.ErrorResponse("Could not retrieve Access Request from Backend"), logical.ErrMissingRequiredState
+	}
+
+	// The justification is never logged, as it may be encrypted in storage
 	b.Logger().Info("[+] Access Request Created",
-		"Object", accessRequest,
+		"RequestorID", accessRequest.OwnerID,
+		"Status", accessRequest.Status,
 	)
 
 	err = b.StoreRequest(ctx, req, accessRequest)
@@ -133,7 +219,10 @@ func (b *BaseBackend) handleRequestUpdate(ctx context.Context, req *logical.Requ
 		Status:            accessRequest.Status,
 		NumOfApprovals:    len(accessRequest.Approvals),
 
-		ClaimTTL: accessRequest.ClaimTTL,
+		ClaimTTL: accessRequest.ClaimTTL / time.Second,
+
+		ConfigVersions: accessRequest.ConfigVersions,
+		Access:         accessResponse(accessRequest.Access),
 	}
 
 	responseData, err := StructToMap(responseObj)
@@ -151,8 +240,9 @@ func (b *BaseBackend) handleRequestRead(ctx context.Context, req *logical.Reques
 	if entityID == "" {
 		return logical.ErrorResponse("Token has no EntityID assigned"), logical.ErrPermissionDenied
 	}
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	lock := b.RequestLock(requestID)
+	lock.RLock()
+	defer lock.RUnlock()
 
 	accessRequest, err := b.GetRequest(ctx, req, requestID)
 	if err != nil {
@@ -162,23 +252,7 @@ func (b *BaseBackend) handleRequestRead(ctx context.Context, req *logical.Reques
 		return &logical.Response{Warnings: []string{"Request does not exist"}}, nil
 	}
 
-	responseObj := responses.AccessRequestResponse{
-		Justification: accessRequest.Justification,
-		OwnerID:       accessRequest.OwnerID,
-
-		CreatedAt:  accessRequest.CreatedAt.Unix(),
-		Expiration: accessRequest.Expiration.Unix(),
-		Deletion:   accessRequest.Deletion.Unix(),
-
-		RequiredApprovals: accessRequest.RequiredApprovals,
-		Status:            accessRequest.Status,
-		NumOfApprovals:    len(accessRequest.Approvals),
-
-		ClaimTTL:       accessRequest.ClaimTTL,
-		ClaimCreatedAt: accessRequest.ClaimCreatedAt.Unix(),
-
-		HaveApproved: accessRequest.isApprovedBy(entityID),
-	}
+	responseObj := newAccessRequestResponse(*accessRequest, entityID, b.newEntityResolver(ctx, req))
 
 	responseData, err := StructToMap(responseObj)
 	if err != nil {
@@ -191,37 +265,24 @@ func (b *BaseBackend) handleRequestRead(ctx context.Context, req *logical.Reques
 func (b *BaseBackend) handleRequestList(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
 	entityID := req.EntityID
 
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	filter, err := requestFilterFromFieldData(d, entityID)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrInvalidRequest
+	}
 
 	resultsFull := map[string]interface{}{}
 	results := []string{}
 
-	accessRequests, err := b.ListRequests(ctx, req)
+	accessRequests, next, err := b.ListRequests(ctx, req, filter)
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
+	entities := b.newEntityResolver(ctx, req)
 	for _, accessRequest := range accessRequests {
 		results = append(results, accessRequest.OwnerID)
 
-		responseObj := responses.AccessRequestResponse{
-			Justification: accessRequest.Justification,
-			OwnerID:       accessRequest.OwnerID,
-
-			CreatedAt:  accessRequest.CreatedAt.Unix(),
-			Expiration: accessRequest.Expiration.Unix(),
-			Deletion:   accessRequest.Deletion.Unix(),
-
-			RequiredApprovals: accessRequest.RequiredApprovals,
-			Status:            accessRequest.Status,
-			NumOfApprovals:    len(accessRequest.Approvals),
-
-			ClaimTTL:       accessRequest.ClaimTTL,
-			ClaimCreatedAt: accessRequest.ClaimCreatedAt.Unix(),
-
-			HaveApproved: accessRequest.isApprovedBy(entityID),
-		}
+		responseObj := newAccessRequestResponse(accessRequest, entityID, entities)
 
 		responseData, err := StructToMap(responseObj)
 		if err != nil {
@@ -230,8 +291,107 @@ func (b *BaseBackend) handleRequestList(ctx context.Context, req *logical.Reques
 		resultsFull[accessRequest.OwnerID] = responseData
 	}
 
-	return logical.ListResponseWithInfo(
+	resp := logical.ListResponseWithInfo(
 		results,
 		resultsFull, // for the 'vault list -detailed path/' command
-	), nil
+	)
+	if next != "" {
+		resp.Data["next"] = next
+	}
+	return resp, nil
+}
+
+func requestFilterFromFieldData(d *framework.FieldData, entityID string) (RequestFilter, error) {
+	filter := RequestFilter{
+		RequestorID: d.Get("requestor").(string),
+		SortBy:      d.Get("sort_by").(string),
+		After:       d.Get("after").(string),
+		Limit:       d.Get("limit").(int),
+	}
+	if filter.Limit < 0 {
+		return filter, fmt.Errorf("'limit' cannot be negative")
+	}
+	if filter.SortBy != SortByRequestorID && filter.SortBy != SortByCreation {
+		return filter, fmt.Errorf("'sort_by' must be one of '%s', '%s'", SortByRequestorID, SortByCreation)
+	}
+
+	if statusString := d.Get("status").(string); statusString != "" {
+		status, err := models.ParseAccessRequestStatus(statusString)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = &status
+	}
+	if d.Get("approved_by_me").(bool) {
+		if entityID == "" {
+			return filter, fmt.Errorf("'approved_by_me' requires a Token with an EntityID assigned")
+		}
+		filter.ApprovedBy = entityID
+	}
+	if createdAfter, ok := d.GetOk("created_after"); ok {
+		filter.CreatedAfter = createdAfter.(time.Time)
+	}
+	return filter, nil
+}
+
+func newAccessRequestResponse(accessRequest AccessRequest, entityID string, entities entityResolver) responses.AccessRequestResponse {
+	return responses.AccessRequestResponse{
+		Justification: accessRequest.Justification,
+		OwnerID:       accessRequest.OwnerID,
+		Requestor:     entities.Resolve(accessRequest.OwnerID),
+
+		CreatedAt:  accessRequest.CreatedAt.Unix(),
+		Expiration: accessRequest.Expiration.Unix(),
+		Deletion:   accessRequest.Deletion.Unix(),
+
+		RequiredApprovals: accessRequest.RequiredApprovals,
+		Status:            accessRequest.Status,
+		NumOfApprovals:    len(accessRequest.Approvals),
+
+		ClaimTTL:          accessRequest.ClaimTTL / time.Second,
+		EffectiveClaimTTL: accessRequest.EffectiveClaimTTL() / time.Second,
+		ClaimCreatedAt:    accessRequest.ClaimCreatedAt.Unix(),
+		ClaimID:           accessRequest.ClaimID,
+		LeaseID:           accessRequest.LeaseID,
+
+		RevocationReason:  accessRequest.RevocationReason,
+		RevokedBy:         accessRequest.RevokedBy,
+		RevocationComment: accessRequest.RevocationComment,
+
+		HaveApproved: accessRequest.isApprovedBy(entityID),
+
+		RejectedBy:      accessRequest.RejectedBy,
+		RejectionReason: accessRequest.RejectionReason,
+
+		History:  newRequestEventsResponse(accessRequest.History),
+		Comments: newRequestCommentsResponse(accessRequest.Comments),
+
+		ConfigVersions: accessRequest.ConfigVersions,
+		Access:         accessResponse(accessRequest.Access),
+	}
+}
+
+func newRequestEventsResponse(events []RequestEvent) []responses.RequestEventResponse {
+	eventsResponse := []responses.RequestEventResponse{}
+	for _, event := range events {
+		eventsResponse = append(eventsResponse, responses.RequestEventResponse{
+			Action:    event.Action,
+			ActorID:   event.ActorID,
+			CreatedAt: event.CreatedAt.Unix(),
+		})
+	}
+	return eventsResponse
+}
+
+func newRequestCommentsResponse(comments []RequestComment) []responses.RequestCommentResponse {
+	commentsResponse := []responses.RequestCommentResponse{}
+	for _, comment := range comments {
+		commentsResponse = append(commentsResponse, responses.RequestCommentResponse{
+			AuthorID:  comment.AuthorID,
+			Role:      comment.Role,
+			Comment:   comment.Comment,
+			CreatedAt: comment.CreatedAt.Unix(),
+		})
+	}
+	return commentsResponse
 }
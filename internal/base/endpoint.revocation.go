@@ -0,0 +1,185 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// Path for listing the revocations of claimed access that failed
+func PathRevocationsPending(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "revocations/pending(/" + framework.GenericNameRegex("id") + ")?/?",
+		Fields: map[string]*framework.FieldSchema{
+			"id": {
+				Type:        framework.TypeString,
+				Description: "The ID of the pending revocation",
+				Required:    false,
+			},
+		},
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ListOperation: b.handleRevocationsPendingList,
+			logical.ReadOperation: b.handleRevocationsPendingRead,
+		},
+
+		HelpSynopsis: "Lists the revocations of claimed access that are retried",
+		HelpDescription: `When the access of a claim cannot be removed as its lease is revoked
+		(e.g.: the external API is unreachable), the removal is queued and retried periodically
+		with exponential backoff, while its AccessRequest stays 'active'.
+
+		This endpoint lists the pending revocations (using 'list')
+		and returns a pending revocation (using 'read' on 'revocations/pending/<id>').
+		`,
+	}
+}
+
+// Path for retrying the revocations of claimed access that failed
+func PathRevocationsRetry(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "revocations/retry",
+		Fields: map[string]*framework.FieldSchema{
+			"id": {
+				Type:        framework.TypeString,
+				Description: "The ID of the pending revocation to retry (all if unset)",
+				Required:    false,
+			},
+		},
+		Operations: map[logical.Operation]framework.OperationHandler{
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleRevocationsRetry,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+		},
+
+		HelpSynopsis: "Retries the revocations of claimed access that failed",
+		HelpDescription: `This endpoint retries the pending revocation 'id',
+		or all pending revocations if 'id' is not set, regardless of their backoff.
+
+		The response contains the completed revocations and the ones still pending.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleRevocationsPendingList(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	revocations, err := b.ListPendingRevocations(ctx, req.Storage)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+
+	results := []string{}
+	resultsFull := map[string]interface{}{}
+	for _, revocation := range revocations {
+		results = append(results, revocation.ID)
+
+		responseData, err := StructToMap(newPendingRevocationResponse(revocation))
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprint(err)), nil
+		}
+		resultsFull[revocation.ID] = responseData
+	}
+
+	return logical.ListResponseWithInfo(
+		results,
+		resultsFull, // for the 'vault list -detailed path/' command
+	), nil
+}
+
+func (b *BaseBackend) handleRevocationsPendingRead(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	revocationID := d.Get("id").(string)
+	if revocationID == "" {
+		return b.handleRevocationsPendingList(ctx, req, d)
+	}
+
+	revocation, err := b.GetPendingRevocationFromStorage(ctx, req.Storage, revocationID)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	if revocation == nil {
+		return &logical.Response{Warnings: []string{"Pending revocation does not exist"}}, nil
+	}
+
+	responseData, err := StructToMap(newPendingRevocationResponse(revocation))
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	return &logical.Response{Data: responseData}, nil
+}
+
+func (b *BaseBackend) handleRevocationsRetry(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	revocationID := d.Get("id").(string)
+	b.Logger().Info("[*] Retrying pending revocations",
+		"RevocationID", revocationID,
+		"EntityID", req.EntityID,
+	)
+
+	completed := []string{}
+	if revocationID == "" {
+		var err error
+		completed, err = b.RetryRevocations(ctx, req, true)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+		}
+	} else {
+		revocation, err := b.GetPendingRevocationFromStorage(ctx, req.Storage, revocationID)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+		}
+		if revocation == nil {
+			return &logical.Response{Warnings: []string{"Pending revocation does not exist"}}, nil
+		}
+		done, err := b.RetryRevocation(ctx, req, revocationID)
+		if err != nil {
+			return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+		}
+		if done {
+			completed = append(completed, revocationID)
+		}
+	}
+
+	revocations, err := b.ListPendingRevocations(ctx, req.Storage)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+	responseObj := responses.RevocationRetryResponse{
+		Completed: completed,
+		Pending:   []responses.PendingRevocationResponse{},
+	}
+	for _, revocation := range revocations {
+		responseObj.Pending = append(responseObj.Pending, newPendingRevocationResponse(revocation))
+	}
+
+	responseData, err := StructToMap(responseObj)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	return &logical.Response{Data: responseData}, nil
+}
+
+func newPendingRevocationResponse(revocation *PendingRevocation) responses.PendingRevocationResponse {
+	return responses.PendingRevocationResponse{
+		ID:          revocation.ID,
+		RequestorID: revocation.RequestorID,
+
+		CreatedAt:   revocation.CreatedAt.Unix(),
+		LastAttempt: revocation.LastAttempt.Unix(),
+		NextAttempt: revocation.NextAttempt.Unix(),
+
+		Attempts:  revocation.Attempts,
+		LastError: revocation.LastError,
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// Path for administrators to revoke the claim of a requestor
+func PathRevoke(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "revoke/" + framework.GenericNameRegex("requestor_id"),
+		Fields: map[string]*framework.FieldSchema{
+			"requestor_id": {
+				Type:        framework.TypeString,
+				Description: "The Entity ID of the requestor whose claim is revoked",
+				Required:    true,
+			},
+			"reason": {
+				Type:        framework.TypeString,
+				Description: "Why the claim is revoked",
+				Required:    true,
+			},
+		},
+		Operations: map[logical.Operation]framework.OperationHandler{
+			// Revocation runs the Remove hook, which reaches the external API
+			logical.UpdateOperation: &framework.PathOperation{
+				Callback:                    b.handleRevoke,
+				ForwardPerformanceStandby:   true,
+				ForwardPerformanceSecondary: true,
+			},
+		},
+		HelpSynopsis: "Revokes the active claim of a requestor",
+		HelpDescription: `This endpoint removes the access of the 'active' AccessRequest of 'requestor_id'
+		and sets it as 'revoked', recording 'reason' and the caller.
+
+		The lease of the claim is not revoked: Vault/OpenBao generates lease IDs after the claim
+		is responded to and passes them to plugins only when revoking the lease, so the Gate cannot know them,
+		and the plugin system view has no lease operations. The lease grants nothing once
+		its access is removed, and finds it already removed when it expires.
+		It can be revoked using 'sys/leases/revoke' with its 'lease_id' (known to the requestor),
+		or found under 'sys/leases/lookup/<mount>/claim/'.
+		If the access cannot be removed, it is retried under '/revocations/pending'.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleRevoke(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	requestorID := d.Get("requestor_id").(string)
+	reason := strings.TrimSpace(d.Get("reason").(string))
+	if reason == "" {
+		return logical.ErrorResponse("A 'reason' is required to revoke a claim"), logical.ErrInvalidRequest
+	}
+
+	lock := b.RequestLock(requestorID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	accessRequest, err := b.GetRequest(ctx, req, requestorID)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	if accessRequest == nil {
+		return &logical.Response{Warnings: []string{"Request does not exist"}}, nil
+	}
+
+	queued, err := b.revokeClaim(ctx, req, accessRequest, RevocationReasonAdmin, revocationActor(req), reason)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrInvalidRequest
+	}
+
+	responseData, err := StructToMap(newAccessRequestResponse(*accessRequest, req.EntityID, b.newEntityResolver(ctx, req)))
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	resp := &logical.Response{Data: responseData}
+	resp.AddWarning("The lease of the claim is not revoked by the Gate and remains until it expires, without access")
+	if queued {
+		resp.AddWarning("The access could not be removed, its removal is retried under '/revocations/pending'")
+	}
+	return resp, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// Path for reporting operational counters of the backend
+func PathStats(b *BaseBackend) *framework.Path {
+	return &framework.Path{
+		Pattern: "stats",
+		Callbacks: map[logical.Operation]framework.OperationFunc{
+			logical.ReadOperation: b.handleStatsRead,
+		},
+		HelpSynopsis: "Reports operational counters of this backend",
+		HelpDescription: `This endpoint reports counters meant for monitoring and alerting.
+
+		'pending_revocations' is the number of claims whose access could not be removed yet
+		(listed under '/revocations/pending'), and should be alerted on when not 0.
+		`,
+	}
+}
+
+func (b *BaseBackend) handleStatsRead(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
+	revocations, err := b.ListPendingRevocations(ctx, req.Storage)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+	}
+
+	responseObj := responses.StatsResponse{
+		PendingRevocations: len(revocations),
+	}
+	if len(revocations) > 0 {
+		// Pending revocations are listed oldest first
+		responseObj.OldestPendingRevocation = revocations[0].CreatedAt.Unix()
+	}
+
+	responseData, err := StructToMap(responseObj)
+	if err != nil {
+		return logical.ErrorResponse(fmt.Sprint(err)), nil
+	}
+	return &logical.Response{Data: responseData}, nil
+}
@@ -0,0 +1,273 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// Replaces the values of sensitive configuration keys in logs
+const RedactedValue = "<redacted>"
+
+/*
+	Configuration fields holding secrets are tagged with `sensitive:"true"`, e.g:
+
+		ApiToken string `json:"api_token" sensitive:"true"`
+
+	Their values are never logged and never returned by read endpoints (write-only).
+*/
+
+// SensitiveConfigurationKeys returns the JSON keys of the fields of 'config' tagged as sensitive
+func SensitiveConfigurationKeys(config interface{}) map[string]struct{} {
+	keys := map[string]struct{}{}
+
+	t := reflect.TypeOf(config)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return keys
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("sensitive") != "true" {
+			continue
+		}
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		if key == "" {
+			key = field.Name
+		}
+		keys[key] = struct{}{}
+	}
+	return keys
+}
+
+// IsSensitiveConfigurationKey returns whether 'key' of 'config' holds a secret
+func IsSensitiveConfigurationKey(config interface{}, key string) bool {
+	_, ok := SensitiveConfigurationKeys(config)[key]
+	return ok
+}
+
+// RedactConfiguration returns 'config' as a map with its sensitive values redacted, to be logged
+func RedactConfiguration(config interface{}) map[string]interface{} {
+	configMap, err := StructToMap(config)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	for key := range SensitiveConfigurationKeys(config) {
+		if value, ok := configMap[key]; ok && value != "" {
+			configMap[key] = RedactedValue
+		}
+	}
+	return configMap
+}
+
+// Field of all configuration endpoints, validating a write without storing it
+const ConfigDryRunKey = "dry_run"
+
+var ConfigDryRunField = &framework.FieldSchema{
+	Type:        framework.TypeBool,
+	Description: "Validate the configuration and return the would-be result, without storing it",
+	Required:    false,
+}
+
+// ApplyConfigurationFields sets the provided fields of the request to 'config'
+func ApplyConfigurationFields(b *BaseBackend, req *logical.Request, config PluginConfig, d *framework.FieldData) error {
+	for key := range d.Raw {
+		if key == ConfigDryRunKey {
+			continue
+		}
+		if _, ok := d.Schema[key]; !ok {
+			continue
+		}
+		value, ok, err := d.GetOkErr(key)
+		if err != nil {
+			return &ConfigTypeError{Key: key, Expected: d.Schema[key].Type.String()}
+		}
+		if !ok {
+			continue
+		}
+
+		loggedValue := value
+		if IsSensitiveConfigurationKey(config, key) {
+			loggedValue = RedactedValue
+		}
+		b.Logger().Info("[*] Replacing configuration value",
+			"Path", req.Path,
+			"EntityID", req.EntityID,
+			"ConfigKey", key,
+			// "OldValue", config,
+			"NewValue", loggedValue,
+		)
+
+		if err := config.SetConfigurationKey(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateConfiguration applies the fields of the request to 'config', validates it
+// and stores it under 'path' (the request path if empty), unless 'dry_run' is set.
+// If the configuration is protected, a ConfigProposal is returned instead of storing it.
+// It returns whether the configuration was stored. The caller must hold 'ConfigMutex'.
+func UpdateConfiguration[T PluginConfig](ctx context.Context, b *BaseBackend, req *logical.Request, d *framework.FieldData, config T, path string) (*ConfigProposal, bool, error) {
+	if err := ApplyConfigurationFields(b, req, config, d); err != nil {
+		return nil, false, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, false, err
+	}
+	if err := b.validateConfigurationConsistency(ctx, req.Storage, config); err != nil {
+		return nil, false, err
+	}
+
+	if dryRun, ok := d.GetOk(ConfigDryRunKey); ok && dryRun.(bool) {
+		b.Logger().Info("[*] Configuration validated without storing it (dry run)",
+			"Path", req.Path,
+			"EntityID", req.EntityID,
+		)
+		return nil, false, nil
+	}
+	// If called without 'path', assume it from the request
+	if path == "" {
+		path = req.Path
+	}
+
+	protection, err := b.configProtection(ctx, req.Storage)
+	if err != nil {
+		return nil, false, err
+	}
+	if protection != nil {
+		section, ok := b.configSection(path)
+		if !ok {
+			return nil, false, &UnknownConfigKeyError{Key: path}
+		}
+		current, err := b.getConfigSectionFromStorage(ctx, req.Storage, section)
+		if err != nil {
+			return nil, false, err
+		}
+		proposal, err := b.proposeConfigurations(ctx, req, protection,
+			map[string]PluginConfig{path: config},
+			map[string]map[string]responses.ConfigChangeResponse{path: ConfigurationDiff(current, config)},
+		)
+		return proposal, false, err
+	}
+	return nil, true, b.StoreConfigurationVersion(ctx, req.Storage, config, path, req.EntityID)
+}
+
+// ConfigurationErrorResponse returns the response of a configuration write failing with 'err'
+func ConfigurationErrorResponse(err error) (*logical.Response, error) {
+	if IsConfigurationError(err) {
+		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrInvalidRequest
+	}
+	return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+}
+
+// validateConfigurationConsistency checks the 'candidates' against each other
+// and the stored configurations they depend on
+func (b *BaseBackend) validateConfigurationConsistency(ctx context.Context, storage logical.Storage, candidates ...PluginConfig) error {
+	var config *Config
+	var configLease *ConfigLease
+	for _, candidate := range candidates {
+		switch c := candidate.(type) {
+		case *Config:
+			config = c
+		case *ConfigLease:
+			configLease = c
+		}
+	}
+	if config == nil && configLease == nil {
+		return nil
+	}
+
+	var err error
+	if config == nil {
+		config, err = GetConfigurationFromStorage[*Config](ctx, b, storage, ConfigKey)
+	}
+	if configLease == nil && err == nil {
+		configLease, err = GetConfigurationFromStorage[*ConfigLease](ctx, b, storage, ConfigLeaseKey)
+	}
+	if err != nil {
+		return err
+	}
+	return ValidateConfigurationConsistency(config, configLease)
+}
+
+// ConfigurationDiff returns the values of 'newConfig' that differ from 'oldConfig' (of the same type),
+// with durations formatted and sensitive values redacted
+func ConfigurationDiff(oldConfig PluginConfig, newConfig PluginConfig) map[string]responses.ConfigChangeResponse {
+	diff := map[string]responses.ConfigChangeResponse{}
+
+	oldValue := reflect.Indirect(reflect.ValueOf(oldConfig))
+	newValue := reflect.Indirect(reflect.ValueOf(newConfig))
+	if oldValue.Kind() != reflect.Struct || oldValue.Type() != newValue.Type() {
+		return diff
+	}
+
+	for i := 0; i < oldValue.NumField(); i++ {
+		field := oldValue.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		from, to := oldValue.Field(i).Interface(), newValue.Field(i).Interface()
+		if reflect.DeepEqual(from, to) {
+			continue
+		}
+
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		if key == "" {
+			key = field.Name
+		}
+		if field.Tag.Get("sensitive") == "true" {
+			from, to = RedactedValue, RedactedValue
+		} else if _, ok := from.(time.Duration); ok {
+			from, to = from.(time.Duration).String(), to.(time.Duration).String()
+		}
+		diff[key] = responses.ConfigChangeResponse{From: from, To: to}
+	}
+	return diff
+}
+
+// keepConfigurationFields copies to 'dst' the values of 'src' (of the same type) that are not 'provided',
+// and are either sensitive (write-only) or not fields of the configuration endpoint
+func keepConfigurationFields(dst PluginConfig, src PluginConfig, provided map[string]interface{}, fields map[string]*framework.FieldSchema) {
+	dstValue := reflect.Indirect(reflect.ValueOf(dst))
+	srcValue := reflect.Indirect(reflect.ValueOf(src))
+	if dstValue.Kind() != reflect.Struct || dstValue.Type() != srcValue.Type() {
+		return
+	}
+
+	for i := 0; i < dstValue.NumField(); i++ {
+		field := dstValue.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		if _, ok := provided[key]; ok {
+			continue
+		}
+		_, isField := fields[key]
+		if field.Tag.Get("sensitive") == "true" || !isField {
+			dstValue.Field(i).Set(srcValue.Field(i))
+		}
+	}
+}
@@ -13,12 +13,26 @@ package base
 import (
 	"fmt"
 	"time"
+
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
 )
 
 func storageKeyForRequest(requestID string) string {
 	return fmt.Sprintf("%s/%s", RequestKey, requestID)
 }
 
+// RequestLock returns the lock guarding the AccessRequest of 'requestID'.
+// Different requestors hash to (mostly) different locks, so operations on
+// their AccessRequests can proceed in parallel.
+func (b *BaseBackend) RequestLock(requestID string) *locksutil.LockEntry {
+	b.requestLocksOnce.Do(func() {
+		b.requestLocks = locksutil.CreateLocks()
+	})
+	return locksutil.LockForKey(b.requestLocks, requestID)
+}
+
 func requestHasExpired(accessRequest AccessRequest) bool {
 	return accessRequest.Expiration.Before(time.Now())
 }
@@ -33,5 +47,76 @@ func validApprovalsNum(accessRequest AccessRequest) int {
 
 func requestIsApproved(accessRequest AccessRequest) bool {
 	numOfvalidApprovals := validApprovalsNum(accessRequest)
-	return numOfvalidApprovals >= accessRequest.RequiredApprovals
+	return approvalQuorumReached(numOfvalidApprovals, accessRequest.RequiredApprovals)
+}
+
+// approvalQuorumReached is the quorum rule of AccessRequests and ConfigProposals
+func approvalQuorumReached(numOfApprovals int, requiredApprovals int) bool {
+	return numOfApprovals >= requiredApprovals
+}
+
+// refreshRequestStatus applies the time and approval based status transitions
+// to the AccessRequest in memory, returning whether its status changed.
+func refreshRequestStatus(accessRequest *AccessRequest) bool {
+	previousStatus := accessRequest.Status
+
+	// Active access is governed by its Vault/OpenBao lease. Request expiry must
+	// not change active state before the lease revocation callback removes access.
+	if (accessRequest.Status != models.Active &&
+		accessRequest.Status != models.Expired &&
+		accessRequest.Status != models.Revoked &&
+		accessRequest.Status != models.Abandoned) && requestHasExpired(*accessRequest) {
+		if accessRequest.Status == models.Pending {
+			accessRequest.Status = models.Abandoned
+		} else {
+			accessRequest.Status = models.Expired
+		}
+	}
+
+	// Handle Approval Status
+	if accessRequest.Status == models.Pending &&
+		requestIsApproved(*accessRequest) {
+		accessRequest.Status = models.Approved
+	}
+	return accessRequest.Status != previousStatus
+}
+
+// Key of the claim data holding the claim time of the AccessRequest
+const ClaimCreatedAtKey = "claim_iat"
+
+// isClaimOfLease returns whether the AccessRequest is the one claimed by the lease with 'leaseData'
+// (assumed for leases created before claims recorded their time)
+func isClaimOfLease(accessRequest AccessRequest, leaseData map[string]interface{}) bool {
+	if claimID, ok := leaseData[ClaimIDKey].(string); ok && accessRequest.ClaimID != "" {
+		return accessRequest.ClaimID == claimID
+	}
+	claimCreatedAt, ok := leaseData[ClaimCreatedAtKey].(string)
+	if !ok {
+		return true
+	}
+	claimedAt, err := time.Parse(time.RFC3339Nano, claimCreatedAt)
+	if err != nil {
+		return true
+	}
+	return accessRequest.ClaimCreatedAt.Equal(claimedAt)
+}
+
+// claimLeaseData returns the data of the access granted by a claim lease ('leaseData'),
+// which is the data of the AccessRequest if it was re-granted since. It returns false if the
+// access of the lease was already removed (revoked on configuration change, or superseded by a later claim).
+func claimLeaseData(accessRequest *AccessRequest, leaseData map[string]interface{}) (map[string]interface{}, bool) {
+	if accessRequest == nil {
+		return leaseData, true
+	}
+	if !isClaimOfLease(*accessRequest, leaseData) {
+		// A later claim can only be active if the access of this one was removed
+		return leaseData, accessRequest.Status != models.Active
+	}
+	if accessRequest.Status == models.Revoked {
+		return nil, false
+	}
+	if accessRequest.ClaimData != nil {
+		return accessRequest.ClaimData, true
+	}
+	return leaseData, true
 }
@@ -0,0 +1,375 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+/* Schema Versions of persisted entries */
+const RequestSchemaVersion = 1
+const ConfigSchemaVersion = 1
+
+// Migration upgrades the persisted entries of the backend to 'Version'
+type Migration struct {
+	Version     int
+	Description string
+	Apply       func(ctx context.Context, b *BaseBackend, storage logical.Storage) error
+}
+
+// Migrations are applied in order, each one exactly once
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "Store AccessRequest 'claim_ttl' as a duration instead of seconds and version all configurations",
+		Apply: func(ctx context.Context, b *BaseBackend, storage logical.Storage) error {
+			if err := b.migrateRequests(ctx, storage); err != nil {
+				return err
+			}
+			return b.migrateConfigurations(ctx, storage)
+		},
+	},
+	{
+		Version:     2,
+		Description: "Index AccessRequests by status",
+		Apply: func(ctx context.Context, b *BaseBackend, storage logical.Storage) error {
+			return b.indexRequests(ctx, storage)
+		},
+	},
+	{
+		Version:     3,
+		Description: "Rewrite API credential configurations so they are seal-wrapped",
+		Apply: func(ctx context.Context, b *BaseBackend, storage logical.Storage) error {
+			return b.rewriteConfigurations(ctx, storage, ConfigAPIKeyPrefix)
+		},
+	},
+	{
+		Version:     4,
+		Description: "Set the default 'config_required_approvals' on the plugin configuration",
+		Apply: func(ctx context.Context, b *BaseBackend, storage logical.Storage) error {
+			return b.setConfigurationDefault(ctx, storage, ConfigKey, "config_required_approvals", NewConfig().ConfigRequiredApprovals)
+		},
+	},
+	{
+		Version:     5,
+		Description: "Set the default 'on_config_change' on the plugin configuration",
+		Apply: func(ctx context.Context, b *BaseBackend, storage logical.Storage) error {
+			return b.setConfigurationDefault(ctx, storage, ConfigKey, "on_config_change", NewConfig().OnConfigChange)
+		},
+	},
+	{
+		Version:     6,
+		Description: "Set the default 'reconcile_interval' on the plugin configuration",
+		Apply: func(ctx context.Context, b *BaseBackend, storage logical.Storage) error {
+			return b.setConfigurationDefault(ctx, storage, ConfigKey, "reconcile_interval", NewConfig().ReconcileInterval)
+		},
+	},
+}
+
+// LatestSchemaVersion is the schema version of the backend after all Migrations are applied
+func LatestSchemaVersion() int {
+	return Migrations[len(Migrations)-1].Version
+}
+
+// MigrationRecord is the outcome of a Migration run
+type MigrationRecord struct {
+	Version     int       `json:"version"`
+	Description string    `json:"description"`
+	AppliedAt   time.Time `json:"applied_at"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// MigrationStatus is stored under 'MigrationsKey'
+type MigrationStatus struct {
+	SchemaVersion int               `json:"schema_version"`
+	LastRun       time.Time         `json:"last_run"`
+	Records       []MigrationRecord `json:"records"`
+}
+
+func (b *BaseBackend) GetMigrationStatusFromStorage(ctx context.Context, storage logical.Storage) (*MigrationStatus, error) {
+	status := &MigrationStatus{Records: []MigrationRecord{}}
+
+	entry, err := storage.Get(ctx, MigrationsKey)
+	if err != nil {
+		b.Logger().Error("[-] Could not retrieve migration status from storage",
+			"error", err,
+		)
+		return nil, fmt.Errorf("Could not retrieve migration status from BaseBackend")
+	}
+	if entry == nil {
+		return status, nil
+	}
+	if err := json.Unmarshal(entry.Value, status); err != nil {
+		b.Logger().Error("[-] Failed to unmarshal MigrationStatus",
+			"error", err,
+		)
+		return nil, fmt.Errorf("Migration status could not be retrieved")
+	}
+	return status, nil
+}
+
+func (b *BaseBackend) storeMigrationStatusToStorage(ctx context.Context, storage logical.Storage, status *MigrationStatus) error {
+	statusJSON, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return storage.Put(ctx, &logical.StorageEntry{
+		Key:   MigrationsKey,
+		Value: statusJSON,
+	})
+}
+
+// RunMigrations applies all Migrations newer than the stored schema version,
+// stopping at the first one that fails.
+func (b *BaseBackend) RunMigrations(ctx context.Context, storage logical.Storage) error {
+	status, err := b.GetMigrationStatusFromStorage(ctx, storage)
+	if err != nil {
+		return err
+	}
+
+	// Storage migrated by a newer version of the plugin cannot be downgraded
+	if status.SchemaVersion > LatestSchemaVersion() {
+		return fmt.Errorf("storage schema version %d is newer than the latest supported (%d)",
+			status.SchemaVersion, LatestSchemaVersion(),
+		)
+	}
+
+	status.LastRun = time.Now()
+	for _, migration := range Migrations {
+		if migration.Version <= status.SchemaVersion {
+			continue
+		}
+		b.Logger().Info("[*] Applying storage migration",
+			"Version", migration.Version,
+			"Description", migration.Description,
+		)
+
+		record := MigrationRecord{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now(),
+		}
+		migrationErr := migration.Apply(ctx, b, storage)
+		if migrationErr != nil {
+			record.Error = migrationErr.Error()
+		} else {
+			status.SchemaVersion = migration.Version
+		}
+		status.Records = append(status.Records, record)
+
+		if err := b.storeMigrationStatusToStorage(ctx, storage, status); err != nil {
+			b.Logger().Error("[-] Could not store migration status",
+				"Version", migration.Version,
+				"error", err,
+			)
+			return err
+		}
+		if migrationErr != nil {
+			b.Logger().Error("[-] Storage migration failed",
+				"Version", migration.Version,
+				"error", migrationErr,
+			)
+			return migrationErr
+		}
+	}
+	return b.storeMigrationStatusToStorage(ctx, storage, status)
+}
+
+// upgradeAccessRequest converts an AccessRequest read from storage
+// to 'RequestSchemaVersion', returning whether it was changed.
+func upgradeAccessRequest(accessRequest *AccessRequest) bool {
+	upgraded := false
+	if accessRequest.SchemaVersion < 1 {
+		// ClaimTTL used to hold a number of seconds
+		accessRequest.ClaimTTL = accessRequest.ClaimTTL * time.Second
+		accessRequest.SchemaVersion = 1
+		upgraded = true
+	}
+	return upgraded
+}
+
+func (b *BaseBackend) migrateRequests(ctx context.Context, storage logical.Storage) error {
+	entries, err := storage.List(ctx, storageKeyForRequest(""))
+	if err != nil {
+		return fmt.Errorf("unable to list requests: %w", err)
+	}
+
+	for _, requestID := range entries {
+		if err := b.migrateRequest(ctx, storage, requestID); err != nil {
+			return fmt.Errorf("unable to migrate request '%s': %w", requestID, err)
+		}
+	}
+	return nil
+}
+
+func (b *BaseBackend) migrateRequest(ctx context.Context, storage logical.Storage, requestID string) error {
+	lock := b.RequestLock(requestID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	entry, err := storage.Get(ctx, storageKeyForRequest(requestID))
+	if err != nil || entry == nil {
+		return err
+	}
+	requestJSON, _, err := b.decodeRequestEntry(ctx, storage, requestID, entry.Value)
+	if err != nil {
+		return err
+	}
+	var accessRequest AccessRequest
+	if err := json.Unmarshal(requestJSON, &accessRequest); err != nil {
+		return err
+	}
+	if !upgradeAccessRequest(&accessRequest) {
+		return nil
+	}
+	return b.StoreRequestToStorage(ctx, storage, &accessRequest)
+}
+
+// migrateConfigurations stamps the schema version on all stored configurations,
+// including the ones of the Gates ('config/access', 'config/api/*').
+func (b *BaseBackend) migrateConfigurations(ctx context.Context, storage logical.Storage) error {
+	keys, err := logical.CollectKeysWithPrefix(ctx, storage, ConfigKey)
+	if err != nil {
+		return fmt.Errorf("unable to list configurations: %w", err)
+	}
+
+	for _, key := range keys {
+		if key != ConfigKey && !strings.HasPrefix(key, ConfigKey+"/") {
+			continue
+		}
+		entry, err := storage.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			// Deleted since it was listed
+			continue
+		}
+
+		version, err := configurationSchemaVersion(entry)
+		if err != nil {
+			return err
+		}
+		if version >= ConfigSchemaVersion {
+			continue
+		}
+
+		configJSON, err := marshalVersionedConfiguration(json.RawMessage(entry.Value))
+		if err != nil {
+			return err
+		}
+		err = storage.Put(ctx, &logical.StorageEntry{
+			Key:   key,
+			Value: configJSON,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to migrate configuration '%s': %w", key, err)
+		}
+	}
+	return nil
+}
+
+// rewriteConfigurations stores again the configurations under 'prefix' as they are,
+// so the current storage options (e.g: seal-wrapping) apply to them.
+func (b *BaseBackend) rewriteConfigurations(ctx context.Context, storage logical.Storage, prefix string) error {
+	keys, err := storage.List(ctx, prefix)
+	if err != nil {
+		return fmt.Errorf("unable to list configurations: %w", err)
+	}
+
+	for _, key := range keys {
+		entry, err := storage.Get(ctx, prefix+key)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			// Deleted since it was listed
+			continue
+		}
+		if _, err := configurationSchemaVersion(entry); err != nil {
+			return err
+		}
+		err = storage.Put(ctx, &logical.StorageEntry{
+			Key:      entry.Key,
+			Value:    entry.Value,
+			SealWrap: true,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to rewrite configuration '%s': %w", entry.Key, err)
+		}
+	}
+	return nil
+}
+
+// setConfigurationDefault sets 'value' to the 'key' of the configuration stored under 'path',
+// if the configuration does not have it (e.g: a key added after it was stored).
+func (b *BaseBackend) setConfigurationDefault(ctx context.Context, storage logical.Storage, path string, key string, value interface{}) error {
+	entry, err := storage.Get(ctx, path)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		// Nothing to migrate, the configuration is stored with all its defaults when created
+		return nil
+	}
+	if _, err := configurationSchemaVersion(entry); err != nil {
+		return err
+	}
+
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(entry.Value, &config); err != nil {
+		return fmt.Errorf("unable to migrate configuration '%s': %w", path, err)
+	}
+	if _, ok := config[key]; ok {
+		return nil
+	}
+
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	config[key] = valueJSON
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	err = storage.Put(ctx, &logical.StorageEntry{
+		Key:   path,
+		Value: configJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to migrate configuration '%s': %w", path, err)
+	}
+	return nil
+}
+
+// configurationSchemaVersion returns the schema version of the stored configuration 'entry'
+// (0 if stored before configurations were versioned).
+// Configurations stored by a newer version of the plugin cannot be migrated, and return an error.
+func configurationSchemaVersion(entry *logical.StorageEntry) (int, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal(entry.Value, &config); err != nil {
+		return 0, fmt.Errorf("unable to migrate configuration '%s': %w", entry.Key, err)
+	}
+	version, _ := config[ConfigSchemaVersionKey].(float64)
+	if int(version) > ConfigSchemaVersion {
+		return 0, fmt.Errorf("unable to migrate configuration '%s': schema version %d is newer than the latest supported (%d)",
+			entry.Key, int(version), ConfigSchemaVersion,
+		)
+	}
+	return int(version), nil
+}
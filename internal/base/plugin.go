@@ -13,8 +13,10 @@ package base
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/helper/locksutil"
 	"github.com/hashicorp/vault/sdk/logical"
 
 	"github.com/gateplane-io/vault-plugins/internal/utils"
@@ -25,15 +27,70 @@ const RequestKey = "request"
 const ConfigKey = "config"
 const ConfigLeaseKey = "config/lease"
 
+// Configurations holding API credentials are stored under this prefix
+// and are seal-wrapped (see 'SealWrappedPaths')
+const ConfigAPIKeyPrefix = "config/api/"
+const MigrationsKey = "migrations"
+const FreezeKey = "freeze"
+const IndexKey = "index"
+
+// Plugin-managed keys are stored under this prefix (seal-wrapped)
+const KeysKeyPrefix = "keys/"
+const RequestKeyringKey = KeysKeyPrefix + "requests"
+
+// Configuration histories are stored under this prefix (seal-wrapped)
+const ConfigHistoryKeyPrefix = "history/"
+
+// Configuration writes awaiting approval are stored under this prefix (seal-wrapped)
+const ConfigProposalKeyPrefix = "proposal/"
+
+// Revocations of claimed access to be retried are stored under this prefix
+const RevocationKeyPrefix = "revocation/"
+
+// Key holding the schema version in every stored configuration
+const ConfigSchemaVersionKey = "schema_version"
+
+// SealWrappedPaths are set as 'PathsSpecial.SealWrapStorage' by every Gate
+var SealWrappedPaths = []string{
+	ConfigAPIKeyPrefix,
+	KeysKeyPrefix,
+	ConfigHistoryKeyPrefix,
+	ConfigProposalKeyPrefix,
+}
+
 type BaseBackend struct {
 	*framework.Backend
-	BaseMutex  sync.Mutex
-	ClaimArray *utils.CallbackArray
+	// ConfigMutex guards the 'config*' storage entries.
+	// AccessRequests are guarded by per-request locks (see 'RequestLock').
+	ConfigMutex sync.RWMutex
+	ClaimArray  *utils.CallbackArray
+	// ConfigSections are the configurations handled by '/config/bundle',
+	// set in 'Initialize' and extended by every Gate
+	ConfigSections []ConfigSection
+	// AccessConfigKey is the configuration of the access granted by the Gate,
+	// snapshotted on AccessRequests at creation (set by every Gate)
+	AccessConfigKey string
+	// HealthChecks are reported by '/health',
+	// set in 'Initialize' and extended by every Gate
+	HealthChecks []HealthCheck
+
+	requestLocks     []*locksutil.LockEntry
+	requestLocksOnce sync.Once
+
+	keyringMutex sync.RWMutex
+	// reconcileMutex serializes reconciliations
+	reconcileMutex sync.Mutex
+	// freezeMutex guards the 'freeze' storage entry
+	freezeMutex sync.Mutex
+	// entityCache keeps the entities resolved for responses (see 'EntityCacheTTL')
+	entityCache          map[string]cachedEntity
+	entityCacheEvictedAt time.Time
+	entityCacheMutex     sync.Mutex
 }
 
 func (b *BaseBackend) Initialize(ctx context.Context, req *logical.InitializationRequest) error {
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	b.ConfigMutex.Lock()
+	defer b.ConfigMutex.Unlock()
 	b.Logger().Info("Initializing plugin Base configuration")
 
 	// If configuration NOT THERE - load default
@@ -54,5 +111,57 @@ func (b *BaseBackend) Initialize(ctx context.Context, req *logical.Initializatio
 		"Existing", exists,
 		"Error", err,
 	)
+
+	b.ConfigSections = []ConfigSection{
+		ConfigSectionConfig(b),
+		ConfigSectionConfigLease(b),
+	}
+	b.HealthChecks = []HealthCheck{
+		HealthCheckConfig(b),
+		HealthCheckRequestKeyring(b),
+	}
+
+	// Migrations are run by the node that can write to storage.
+	// Reads upgrade older AccessRequests in memory until then.
+	if b.WriteSafeReplicationState() {
+		err = b.EnsureRequestKeyring(ctx, req.Storage)
+		if err != nil {
+			b.Logger().Error("[-] Could not initialize the request keyring",
+				"error", err,
+			)
+		}
+
+		err = b.RunMigrations(ctx, req.Storage)
+		if err != nil {
+			b.Logger().Error("[-] Could not migrate storage to the latest schema",
+				"SchemaVersion", LatestSchemaVersion(),
+				"error", err,
+			)
+		}
+	}
 	return nil
 }
+
+// Periodic is run by the Vault/OpenBao RollbackManager.
+// Storage is only written where it is writable (active node of the primary cluster).
+func (b *BaseBackend) Periodic(ctx context.Context, req *logical.Request) error {
+	if !b.WriteSafeReplicationState() {
+		return nil
+	}
+	if err := b.TidyConfigProposals(ctx, req.Storage); err != nil {
+		b.Logger().Error("[-] Could not tidy ConfigProposals",
+			"error", err,
+		)
+	}
+	if _, err := b.RetryRevocations(ctx, req, false); err != nil {
+		b.Logger().Error("[-] Could not retry pending revocations",
+			"error", err,
+		)
+	}
+	if err := b.reconcileIfDue(ctx, req); err != nil {
+		b.Logger().Error("[-] Could not reconcile claims",
+			"error", err,
+		)
+	}
+	return b.TidyRequests(ctx, req.Storage)
+}
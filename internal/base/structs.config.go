@@ -11,8 +11,13 @@
 package base
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
 )
 
 type Config struct {
@@ -22,8 +27,34 @@ type Config struct {
 
 	RequestTTL  time.Duration `json:"request_ttl"`
 	DeleteAfter time.Duration `json:"delete_after"`
+
+	// Encrypt stored AccessRequests with the plugin-managed key
+	EncryptRequests bool `json:"encrypt_requests"`
+
+	// Configuration writes create ConfigProposals, applied after 'ConfigRequiredApprovals'
+	ProtectConfig           bool `json:"protect_config"`
+	ConfigRequiredApprovals int  `json:"config_required_approvals"`
+	// Entities (by ID) and identity groups (by name or ID) whose members can approve ConfigProposals
+	ConfigApprovers []string `json:"config_approvers"`
+
+	// Return approved and pending AccessRequests to 'pending' without approvals
+	// when the access configuration of the Gate changes
+	ResetApprovalsOnAccessChange bool `json:"reset_approvals_on_access_change"`
+	// What happens to active claims when the access configuration of the Gate changes
+	OnConfigChange string `json:"on_config_change"`
+
+	// How often the periodic reconciliation compares claims with the granted access (0 disables it)
+	ReconcileInterval time.Duration `json:"reconcile_interval"`
+
+	// Metadata keys of the requestor and approver entities returned in responses
+	EntityMetadataKeys []string `json:"entity_metadata_keys"`
 }
 
+/* Behaviours of active claims on access configuration changes ('on_config_change') */
+const OnConfigChangeKeep = "keep"
+const OnConfigChangeRegrant = "regrant"
+const OnConfigChangeRevoke = "revoke"
+
 func NewConfig() Config {
 	return Config{
 		RequireJustification: false, // Default: Do not require justification
@@ -31,12 +62,86 @@ func NewConfig() Config {
 		// AllowRejection:       true,                 // Default: Allow rejection
 		RequestTTL:  1 * time.Hour,  // Default: 1 hour for request TTL
 		DeleteAfter: 24 * time.Hour, // Default: 24 hours for deletion
+
+		EncryptRequests: false, // Default: Store AccessRequests in plain JSON
+
+		ProtectConfig:           false, // Default: Configuration writes are applied directly
+		ConfigRequiredApprovals: 1,     // Default: Require 1 approval for protected configuration writes
+
+		ResetApprovalsOnAccessChange: false,              // Default: Keep approvals, claims grant the approved access
+		OnConfigChange:               OnConfigChangeKeep, // Default: Active claims keep their access until their lease ends
+
+		ReconcileInterval: 1 * time.Hour, // Default: Reconcile claims with the granted access hourly
 	}
 }
 
 type PluginConfig interface {
 	// For struct to be a Config it needs to properly update its fields independently
 	SetConfigurationKey(key string, value interface{}) error
+	// and check that its fields are consistent, before being stored
+	Validate() error
+}
+
+// ConfigSection describes a configuration endpoint, to be handled as a section of '/config/bundle'
+type ConfigSection struct {
+	// Storage key and path of the configuration (e.g: 'config/lease')
+	Key string
+	// Fields of the configuration endpoint, parsing the values of the section
+	Fields map[string]*framework.FieldSchema
+	// Returns the default configuration
+	Default func() PluginConfig
+	// Returns the configuration, as reported by its endpoint
+	Response func(config PluginConfig) (*logical.Response, error)
+	// Optional, called after the section is stored by '/config/bundle'
+	// (e.g: to reload API clients), returning warnings
+	Applied func(ctx context.Context, req *logical.Request, config PluginConfig) []string
+}
+
+// UnknownConfigKeyError is returned when setting a key that the configuration does not have
+type UnknownConfigKeyError struct {
+	Key string
+}
+
+func (e *UnknownConfigKeyError) Error() string {
+	return fmt.Sprintf("unknown configuration key: %s", e.Key)
+}
+
+// ConfigTypeError is returned when setting a configuration key to a value of the wrong type
+type ConfigTypeError struct {
+	Key      string
+	Expected string
+}
+
+func (e *ConfigTypeError) Error() string {
+	return fmt.Sprintf("invalid type for '%s', expected %s", e.Key, e.Expected)
+}
+
+// ConfigValidationError is returned when a configuration value is out of its bounds,
+// or inconsistent with other configuration values
+type ConfigValidationError struct {
+	Key    string
+	Reason string
+}
+
+func (e *ConfigValidationError) Error() string {
+	return fmt.Sprintf("invalid value for '%s': %s", e.Key, e.Reason)
+}
+
+// IsConfigurationError returns whether 'err' is caused by the provided configuration values
+func IsConfigurationError(err error) bool {
+	var unknownKeyErr *UnknownConfigKeyError
+	var typeErr *ConfigTypeError
+	var validationErr *ConfigValidationError
+	return errors.As(err, &unknownKeyErr) || errors.As(err, &typeErr) || errors.As(err, &validationErr)
+}
+
+// durationSecondsValue converts the value of a 'framework.TypeDurationSecond' field
+func durationSecondsValue(key string, value interface{}) (time.Duration, error) {
+	v, ok := value.(int)
+	if !ok {
+		return 0, &ConfigTypeError{Key: key, Expected: "duration"}
+	}
+	return time.Duration(v) * time.Second, nil
 }
 
 func (c *Config) SetConfigurationKey(key string, value interface{}) error {
@@ -45,20 +150,109 @@ func (c *Config) SetConfigurationKey(key string, value interface{}) error {
 		if v, ok := value.(int); ok {
 			c.RequiredApprovals = v
 		} else {
-			return fmt.Errorf("invalid type for required_approvals, expected int")
+			return &ConfigTypeError{Key: key, Expected: "int"}
 		}
 	case "require_justification":
 		if v, ok := value.(bool); ok {
 			c.RequireJustification = v
 		} else {
-			return fmt.Errorf("invalid type for require_reason, expected bool")
+			return &ConfigTypeError{Key: key, Expected: "bool"}
+		}
+	case "encrypt_requests":
+		if v, ok := value.(bool); ok {
+			c.EncryptRequests = v
+		} else {
+			return &ConfigTypeError{Key: key, Expected: "bool"}
+		}
+	case "protect_config":
+		if v, ok := value.(bool); ok {
+			c.ProtectConfig = v
+		} else {
+			return &ConfigTypeError{Key: key, Expected: "bool"}
+		}
+	case "reset_approvals_on_access_change":
+		if v, ok := value.(bool); ok {
+			c.ResetApprovalsOnAccessChange = v
+		} else {
+			return &ConfigTypeError{Key: key, Expected: "bool"}
+		}
+	case "on_config_change":
+		if v, ok := value.(string); ok {
+			c.OnConfigChange = v
+		} else {
+			return &ConfigTypeError{Key: key, Expected: "string"}
+		}
+	case "config_required_approvals":
+		if v, ok := value.(int); ok {
+			c.ConfigRequiredApprovals = v
+		} else {
+			return &ConfigTypeError{Key: key, Expected: "int"}
+		}
+	case "config_approvers":
+		if v, ok := value.([]string); ok {
+			c.ConfigApprovers = v
+		} else {
+			return &ConfigTypeError{Key: key, Expected: "list of strings"}
+		}
+	case "entity_metadata_keys":
+		if v, ok := value.([]string); ok {
+			c.EntityMetadataKeys = v
+		} else {
+			return &ConfigTypeError{Key: key, Expected: "list of strings"}
 		}
 	case "request_ttl":
-		c.RequestTTL = time.Duration(value.(int)) * time.Second
+		v, err := durationSecondsValue(key, value)
+		if err != nil {
+			return err
+		}
+		c.RequestTTL = v
+	case "reconcile_interval":
+		v, err := durationSecondsValue(key, value)
+		if err != nil {
+			return err
+		}
+		c.ReconcileInterval = v
 	case "delete_after":
-		c.DeleteAfter = time.Duration(value.(int)) * time.Second
+		v, err := durationSecondsValue(key, value)
+		if err != nil {
+			return err
+		}
+		c.DeleteAfter = v
+	default:
+		return &UnknownConfigKeyError{Key: key}
+	}
+	return nil
+}
+
+func (c *Config) Validate() error {
+	if c.RequiredApprovals < 0 {
+		return &ConfigValidationError{Key: "required_approvals", Reason: "cannot be negative"}
+	}
+	if c.ConfigRequiredApprovals < 1 {
+		return &ConfigValidationError{Key: "config_required_approvals", Reason: "must be at least 1"}
+	}
+	if c.ProtectConfig && len(c.ConfigApprovers) == 0 {
+		return &ConfigValidationError{Key: "config_approvers", Reason: "cannot be empty when 'protect_config' is set"}
+	}
+	if c.RequestTTL <= 0 {
+		return &ConfigValidationError{Key: "request_ttl", Reason: "must be positive"}
+	}
+	if c.ReconcileInterval < 0 {
+		return &ConfigValidationError{Key: "reconcile_interval", Reason: "cannot be negative"}
+	}
+	switch c.OnConfigChange {
+	case OnConfigChangeKeep, OnConfigChangeRegrant, OnConfigChangeRevoke:
 	default:
-		return fmt.Errorf("unknown configuration key: %s", key)
+		return &ConfigValidationError{
+			Key:    "on_config_change",
+			Reason: fmt.Sprintf("must be one of '%s', '%s', '%s'", OnConfigChangeKeep, OnConfigChangeRegrant, OnConfigChangeRevoke),
+		}
+	}
+	if c.DeleteAfter < c.RequestTTL {
+		return &ConfigValidationError{
+			Key:    "delete_after",
+			Reason: fmt.Sprintf("(%s) cannot be shorter than 'request_ttl' (%s)", c.DeleteAfter, c.RequestTTL),
+		}
 	}
 	return nil
 }
@@ -78,11 +272,47 @@ func NewConfigLease() ConfigLease {
 func (c *ConfigLease) SetConfigurationKey(key string, value interface{}) error {
 	switch key {
 	case "lease":
-		c.Lease = time.Duration(value.(int)) * time.Second
+		v, err := durationSecondsValue(key, value)
+		if err != nil {
+			return err
+		}
+		c.Lease = v
 	case "lease_max":
-		c.LeaseMax = time.Duration(value.(int)) * time.Second
+		v, err := durationSecondsValue(key, value)
+		if err != nil {
+			return err
+		}
+		c.LeaseMax = v
 	default:
-		return fmt.Errorf("unknown configuration key: %s", key)
+		return &UnknownConfigKeyError{Key: key}
+	}
+	return nil
+}
+
+func (c *ConfigLease) Validate() error {
+	if c.Lease <= 0 {
+		return &ConfigValidationError{Key: "lease", Reason: "must be positive"}
+	}
+	if c.Lease > c.LeaseMax {
+		return &ConfigValidationError{
+			Key:    "lease",
+			Reason: fmt.Sprintf("(%s) cannot be longer than 'lease_max' (%s)", c.Lease, c.LeaseMax),
+		}
+	}
+	return nil
+}
+
+// ValidateConfigurationConsistency checks the values of 'Config' against the ones of 'ConfigLease'
+func ValidateConfigurationConsistency(config *Config, configLease *ConfigLease) error {
+	// An AccessRequest can be claimed until it expires and the claim lasts up to 'lease_max',
+	// it has to be kept in storage until then for its outcome to be inspected
+	if config.DeleteAfter < config.RequestTTL+configLease.LeaseMax {
+		return &ConfigValidationError{
+			Key: "delete_after",
+			Reason: fmt.Sprintf("(%s) cannot be shorter than 'request_ttl' (%s) and 'lease_max' (%s) combined",
+				config.DeleteAfter, config.RequestTTL, configLease.LeaseMax,
+			),
+		}
 	}
 	return nil
 }
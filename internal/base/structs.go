@@ -11,6 +11,7 @@
 package base
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -18,6 +19,22 @@ import (
 	"github.com/gateplane-io/vault-plugins/pkg/models"
 )
 
+/* Reasons of claim revocations */
+// The lease expired, or was revoked outside of the Gate
+const RevocationReasonExpired = "expired"
+
+// The access configuration changed ('on_config_change')
+const RevocationReasonConfigChange = "config_change"
+
+// An administrator revoked the claim ('/revoke')
+const RevocationReasonAdmin = "admin"
+
+// The requestor released the claim ('/release')
+const RevocationReasonReleased = "released"
+
+// The claims of the Gate were killed ('/kill')
+const RevocationReasonKill = "kill"
+
 // AccessRequest
 type AccessRequest struct {
 	OwnerID    string    `json:"owner_id"`
@@ -28,27 +45,64 @@ type AccessRequest struct {
 	Justification     string `json:"justification"` // provided by the requestor
 	RequiredApprovals int    `json:"required_approvals"`
 
-	ClaimCreatedAt time.Time `json:"claim_iat"`
-	// ClaimTTL is stored as a number of seconds for API and storage compatibility.
-	ClaimTTL time.Duration `json:"claim_ttl"`
+	ClaimCreatedAt time.Time     `json:"claim_iat"`
+	ClaimTTL       time.Duration `json:"claim_ttl"`
+	// ClaimID is set by the Gate on the data of the lease of the claim.
+	// It is not the lease ID: Vault/OpenBao generates lease IDs after the claim is responded to,
+	// ignoring the one of the response Secret, and passes them to plugins only when revoking the lease
+	ClaimID string `json:"claim_id,omitempty"`
+	// LeaseID of the claim, recorded when its lease is revoked (the only time it is passed to the Gate)
+	LeaseID string `json:"lease_id,omitempty"`
+
+	// Why and by whom the claim was revoked, recorded before its access is removed
+	// (see 'RevocationReason*')
+	RevocationReason  string `json:"revocation_reason,omitempty"`
+	RevokedBy         string `json:"revoked_by,omitempty"`
+	RevocationComment string `json:"revocation_comment,omitempty"`
+	// Set once reconciliation checked that the access of the revoked or expired claim was removed
+	// (see 'Reconcile'), so that it is not checked again
+	Reconciled bool `json:"reconciled,omitempty"`
 
 	Status    models.AccessRequestStatus `json:"status"`
 	Approvals map[string]*Approval       `json:"approvals"`
-}
 
-func NewAccessRequest(config *Config, configLease *ConfigLease, ownerID string, ttlSeconds time.Duration, justification string) (*AccessRequest, error) {
-	leaseSeconds := configLease.Lease / time.Second
-	leaseMaxSeconds := configLease.LeaseMax / time.Second
+	// Who rejected the AccessRequest, and why
+	RejectedBy      string `json:"rejected_by,omitempty"`
+	RejectionReason string `json:"rejection_reason,omitempty"`
+
+	// Changes to the AccessRequest that are not reflected on its state (e.g.: retracted approvals)
+	History []RequestEvent `json:"history,omitempty"`
+	// Discussion between the requestor and the approvers (see '/request/comments')
+	Comments []RequestComment `json:"comments,omitempty"`
+
+	// Versions of the configurations (by path) that applied when the AccessRequest was created
+	// (see 'ConfigHistory')
+	ConfigVersions map[string]int `json:"config_versions"`
+	// The access configuration when the AccessRequest was created (or last reset),
+	// which is what gets approved and granted on claim (see 'AccessConfigKey')
+	Access json.RawMessage `json:"access,omitempty"`
+	// The data of the access granted by the claim, kept up to date when re-granted
+	// (the claim lease keeps the data of the original grant)
+	ClaimData map[string]interface{} `json:"claim_data,omitempty"`
 
-	if ttlSeconds == 0 {
-		ttlSeconds = leaseSeconds
+	// Version is incremented on every write to storage and is used
+	// as a Compare-And-Swap token by 'StoreRequestToStorage'.
+	Version uint64 `json:"version"`
+	// SchemaVersion is the storage representation of this AccessRequest
+	// (see 'migrations.go')
+	SchemaVersion int `json:"schema_version"`
+}
+
+func NewAccessRequest(config *Config, configLease *ConfigLease, ownerID string, ttl time.Duration, justification string) (*AccessRequest, error) {
+	if ttl == 0 {
+		ttl = configLease.Lease
 	}
 
-	if ttlSeconds < leaseSeconds {
-		return nil, fmt.Errorf("the requested TTL (%s) is lower than the minimum lease of the backend (%s)", ttlSeconds*time.Second, configLease.Lease)
+	if ttl < configLease.Lease {
+		return nil, fmt.Errorf("the requested TTL (%s) is lower than the minimum lease of the backend (%s)", ttl, configLease.Lease)
 	}
-	if ttlSeconds > leaseMaxSeconds {
-		return nil, fmt.Errorf("the requested TTL (%s) is higher than the maximum lease of the backend (%s)", ttlSeconds*time.Second, configLease.LeaseMax)
+	if ttl > configLease.LeaseMax {
+		return nil, fmt.Errorf("the requested TTL (%s) is higher than the maximum lease of the backend (%s)", ttl, configLease.LeaseMax)
 	}
 
 	if config.RequireJustification && strings.TrimSpace(justification) == "" {
@@ -68,10 +122,12 @@ func NewAccessRequest(config *Config, configLease *ConfigLease, ownerID string,
 		Justification:     justification,
 		RequiredApprovals: config.RequiredApprovals,
 
-		ClaimTTL:       ttlSeconds,
+		ClaimTTL:       ttl,
 		ClaimCreatedAt: time.Unix(0, 0),
 
 		Approvals: map[string]*Approval{},
+
+		SchemaVersion: RequestSchemaVersion,
 	}, nil
 }
 
@@ -79,13 +135,49 @@ func (a AccessRequest) Equals(b AccessRequest) bool {
 	return a.OwnerID == b.OwnerID
 }
 
+/* Actions of RequestEvents */
+const (
+	RequestEventApprovalRetracted = "approval_retracted"
+	RequestEventCommentAdded      = "comment_added"
+)
+
+// RequestEvent is an entry of the history of an AccessRequest
+type RequestEvent struct {
+	Action    string    `json:"action"`
+	ActorID   string    `json:"actor_id"`
+	CreatedAt time.Time `json:"iat"`
+}
+
+/* Roles of the authors of RequestComments */
+const (
+	CommentRoleRequestor = "requestor"
+	CommentRoleApprover  = "approver"
+)
+
+// Comments are limited, as they are stored (and recorded in the history) of their AccessRequest
+const RequestCommentMaxLength = 4096
+const RequestCommentsMax = 100
+
+// RequestComment is an entry of the discussion on an AccessRequest
+type RequestComment struct {
+	AuthorID  string    `json:"author_id"`
+	Role      string    `json:"role"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"iat"`
+}
+
 // Approval
 type Approval struct {
 	OwnerID   string    `json:"requestor_id"`
 	CreatedAt time.Time `json:"iat"`
+
+	// Provided by the approver
+	Comment string `json:"comment,omitempty"`
+	// The maximum claim TTL allowed by the approver (0 for no limit)
+	TTL time.Duration `json:"ttl,omitempty"`
 }
 
-func (req *AccessRequest) Approve(approverID string) (*Approval, bool, error) {
+func (req *AccessRequest) Approve(approverID string, comment string, ttl time.Duration) (*Approval, bool, error) {
 	if req.Status != models.Pending {
 		return nil, false, fmt.Errorf(
 			"The AccessRequest cannot be approved, as it is in '%s' state",
@@ -102,18 +194,107 @@ func (req *AccessRequest) Approve(approverID string) (*Approval, bool, error) {
 	approval := &Approval{
 		OwnerID:   approverID,
 		CreatedAt: now,
+		Comment:   comment,
+		TTL:       ttl,
 	}
 
 	req.Approvals[approverID] = approval
 	return approval, lastApproval, nil
 }
 
+// RetractApproval removes the approval of 'approverID' from an AccessRequest that is not claimed yet,
+// returning it to 'pending' if its approvals do not reach the quorum anymore
+func (req *AccessRequest) RetractApproval(approverID string) error {
+	if req.Status != models.Pending && req.Status != models.Approved {
+		return fmt.Errorf(
+			"The approval cannot be retracted, as the AccessRequest is in '%s' state",
+			req.Status,
+		)
+	}
+	if !req.isApprovedBy(approverID) {
+		return fmt.Errorf("The AccessRequest is not approved by this Entity")
+	}
+
+	delete(req.Approvals, approverID)
+	if !requestIsApproved(*req) {
+		req.Status = models.Pending
+	}
+	req.History = append(req.History, RequestEvent{
+		Action:    RequestEventApprovalRetracted,
+		ActorID:   approverID,
+		CreatedAt: time.Now(),
+	})
+	return nil
+}
+
+// AddComment appends a comment by 'authorID' to the discussion of an AccessRequest that is not terminated yet
+func (req *AccessRequest) AddComment(authorID string, comment string) (*RequestComment, error) {
+	if req.Status != models.Pending && req.Status != models.Approved && req.Status != models.Active {
+		return nil, fmt.Errorf(
+			"The AccessRequest cannot be commented on, as it is in '%s' state",
+			req.Status,
+		)
+	}
+	if comment == "" {
+		return nil, fmt.Errorf("'comment' cannot be empty")
+	}
+	if len(comment) > RequestCommentMaxLength {
+		return nil, fmt.Errorf("'comment' cannot be longer than %d bytes", RequestCommentMaxLength)
+	}
+	if len(req.Comments) >= RequestCommentsMax {
+		return nil, fmt.Errorf("The AccessRequest cannot have more than %d comments", RequestCommentsMax)
+	}
+	role := CommentRoleApprover
+	if authorID == req.OwnerID {
+		role = CommentRoleRequestor
+	}
+	now := time.Now()
+	requestComment := RequestComment{
+		AuthorID:  authorID,
+		Role:      role,
+		Comment:   comment,
+		CreatedAt: now,
+	}
+	req.Comments = append(req.Comments, requestComment)
+	req.History = append(req.History, RequestEvent{
+		Action:    RequestEventCommentAdded,
+		ActorID:   authorID,
+		CreatedAt: now,
+	})
+	return &requestComment, nil
+}
+
+// Reject rejects an AccessRequest that is not claimed yet
+func (req *AccessRequest) Reject(rejecterID string, reason string) error {
+	if req.Status != models.Pending && req.Status != models.Approved {
+		return fmt.Errorf(
+			"The AccessRequest cannot be rejected, as it is in '%s' state",
+			req.Status,
+		)
+	}
+	req.Status = models.Rejected
+	req.RejectedBy = rejecterID
+	req.RejectionReason = reason
+	return nil
+}
+
+// EffectiveClaimTTL returns the requested claim TTL, limited by the TTLs of the approvals
+func (req *AccessRequest) EffectiveClaimTTL() time.Duration {
+	ttl := req.ClaimTTL
+	for _, approval := range req.Approvals {
+		if approval.TTL > 0 && approval.TTL < ttl {
+			ttl = approval.TTL
+		}
+	}
+	return ttl
+}
+
 func (req *AccessRequest) isApprovedBy(approverID string) bool {
 	_, ok := req.Approvals[approverID]
 	return ok
 }
 
-func (req *AccessRequest) Claim() error {
+func (req *AccessRequest) Claim(claimID string) error {
 	if req.Status != models.Approved {
 		return fmt.Errorf(
 			"The AccessRequest cannot be claimed, as it is in '%s' state",
@@ -124,6 +305,35 @@ func (req *AccessRequest) Claim() error {
 	now := time.Now()
 	req.Status = models.Active
 	req.ClaimCreatedAt = now
+	req.ClaimTTL = req.EffectiveClaimTTL()
+	req.ClaimID = claimID
+	req.LeaseID = ""
+	req.RecordRevocation("", "", "")
+	req.Reconciled = false
 
 	return nil
 }
+
+// RecordRevocation records why and by whom the claim is revoked, before its access is removed
+func (req *AccessRequest) RecordRevocation(reason string, actor string, comment string) {
+	req.RevocationReason = reason
+	req.RevokedBy = actor
+	req.RevocationComment = comment
+}
+
+// revocationStatus returns the status of the AccessRequest once the access of its claim is removed
+func (req *AccessRequest) revocationStatus() models.AccessRequestStatus {
+	if req.RevocationReason == "" || req.RevocationReason == RevocationReasonExpired {
+		return models.Expired
+	}
+	return models.Revoked
+}
+
+// Revoke sets the status of the AccessRequest after the access of its claim was removed,
+// which is 'expired' unless a revocation was recorded
+func (req *AccessRequest) Revoke() {
+	if req.RevocationReason == "" {
+		req.RevocationReason = RevocationReasonExpired
+	}
+	req.Status = req.revocationStatus()
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package base
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+	"github.com/gateplane-io/vault-plugins/pkg/responses"
+)
+
+// ConfigProposal is a configuration write awaiting approval, created when 'protect_config' is set
+type ConfigProposal struct {
+	ID         string    `json:"id"`
+	OwnerID    string    `json:"owner_id"`
+	CreatedAt  time.Time `json:"iat"`
+	Expiration time.Time `json:"exp"`
+	Deletion   time.Time `json:"deleted_after"`
+
+	// 'pending' until approved and applied ('approved'), expired or withdrawn ('abandoned')
+	// or outdated by another configuration write ('rejected')
+	Status            models.AccessRequestStatus `json:"status"`
+	RequiredApprovals int                        `json:"required_approvals"`
+	Approvals         map[string]*Approval       `json:"approvals"`
+
+	// Set when withdrawn by its owner
+	WithdrawnBy string    `json:"withdrawn_by,omitempty"`
+	WithdrawnAt time.Time `json:"withdrawn_at"`
+
+	// The configurations to store (by path), their changes,
+	// and the versions they were proposed against (see 'ConfigHistory')
+	Configs      map[string]json.RawMessage                           `json:"configs"`
+	Diff         map[string]map[string]responses.ConfigChangeResponse `json:"diff"`
+	BaseVersions map[string]int                                       `json:"base_versions"`
+}
+
+func (p *ConfigProposal) Approve(approverID string) error {
+	if p.Status != models.Pending {
+		return fmt.Errorf(
+			"The ConfigProposal cannot be approved, as it is in '%s' state",
+			p.Status,
+		)
+	}
+	if p.OwnerID == approverID {
+		return fmt.Errorf("Entities cannot approve their own ConfigProposals")
+	}
+
+	p.Approvals[approverID] = &Approval{
+		OwnerID:   approverID,
+		CreatedAt: time.Now(),
+	}
+	return nil
+}
+
+// Withdraw abandons a pending ConfigProposal, which only its owner can do
+func (p *ConfigProposal) Withdraw(entityID string) error {
+	if p.Status != models.Pending {
+		return fmt.Errorf(
+			"The ConfigProposal cannot be withdrawn, as it is in '%s' state",
+			p.Status,
+		)
+	}
+	p.Status = models.Abandoned
+	p.WithdrawnBy = entityID
+	p.WithdrawnAt = time.Now()
+	return nil
+}
+
+func (p *ConfigProposal) isApproved() bool {
+	return approvalQuorumReached(len(p.Approvals), p.RequiredApprovals)
+}
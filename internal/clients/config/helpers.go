@@ -0,0 +1,29 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package config
+
+import (
+	"net/url"
+
+	"github.com/gateplane-io/vault-plugins/internal/base"
+)
+
+// validateApiUrl checks that 'value' of 'key' is an absolute HTTP(S) URL
+func validateApiUrl(key string, value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return &base.ConfigValidationError{Key: key, Reason: err.Error()}
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &base.ConfigValidationError{Key: key, Reason: "must be an absolute 'http' or 'https' URL"}
+	}
+	return nil
+}
@@ -11,12 +11,12 @@
 package config
 
 import (
-	"fmt"
+	"github.com/gateplane-io/vault-plugins/internal/base"
 )
 
 type ConfigApiOkta struct {
 	OrgUrl   string `json:"org_url"`
-	ApiToken string `json:"api_token"`
+	ApiToken string `json:"api_token" sensitive:"true"`
 
 	// OktaEntityKey: "name", // Default when 'user_claim=sub' in OIDC
 	OktaEntityKey string `json:"okta_entity_key"`
@@ -40,28 +40,44 @@ func (c *ConfigApiOkta) SetConfigurationKey(key string, value interface{}) error
 		if v, ok := value.(string); ok {
 			c.OrgUrl = v
 		} else {
-			return fmt.Errorf("invalid type for 'org_url', expected string")
+			return &base.ConfigTypeError{Key: key, Expected: "string"}
 		}
 	case "api_token":
 		if v, ok := value.(string); ok {
 			c.ApiToken = v
 		} else {
-			return fmt.Errorf("invalid type for 'api_token', expected string")
+			return &base.ConfigTypeError{Key: key, Expected: "string"}
 		}
 	case "okta_entity_key":
 		if v, ok := value.(string); ok {
 			c.OktaEntityKey = v
 		} else {
-			return fmt.Errorf("invalid type for 'okta_entity_key', expected string")
+			return &base.ConfigTypeError{Key: key, Expected: "string"}
 		}
 	case "auth_mount_accessor":
 		if v, ok := value.(string); ok {
 			c.OktaOIDCMountAccessor = v
 		} else {
-			return fmt.Errorf("invalid type for 'auth_mount_accessor', expected string")
+			return &base.ConfigTypeError{Key: key, Expected: "string"}
 		}
 	default:
-		return fmt.Errorf("unknown configuration key: %s", key)
+		return &base.UnknownConfigKeyError{Key: key}
+	}
+	return nil
+}
+
+func (c *ConfigApiOkta) Validate() error {
+	// The Okta API can be configured after the rest of the backend
+	if c.OrgUrl != "" {
+		if err := validateApiUrl("org_url", c.OrgUrl); err != nil {
+			return err
+		}
+	}
+	if c.OktaEntityKey == "" && c.OktaOIDCMountAccessor == "" {
+		return &base.ConfigValidationError{
+			Key:    "okta_entity_key",
+			Reason: "cannot be empty when 'auth_mount_accessor' is not set",
+		}
 	}
 	return nil
 }
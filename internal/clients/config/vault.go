@@ -11,13 +11,15 @@
 package config
 
 import (
-	"fmt"
+	"strings"
+
+	"github.com/gateplane-io/vault-plugins/internal/base"
 )
 
 type ConfigApiVaultAppRole struct {
 	Url          string `json:"url"`
 	RoleID       string `json:"role_id"`
-	RoleSecret   string `json:"role_secret"`
+	RoleSecret   string `json:"role_secret" sensitive:"true"`
 	AppRoleMount string `json:"approle_mount"`
 }
 
@@ -29,17 +31,32 @@ func NewConfigApiVaultAppRole() ConfigApiVaultAppRole {
 }
 
 func (c *ConfigApiVaultAppRole) SetConfigurationKey(key string, value interface{}) error {
+	v, ok := value.(string)
+	if !ok {
+		return &base.ConfigTypeError{Key: key, Expected: "string"}
+	}
+
 	switch key {
 	case "url":
-		c.Url = value.(string)
+		c.Url = v
 	case "role_id":
-		c.RoleID = value.(string)
+		c.RoleID = v
 	case "role_secret":
-		c.RoleSecret = value.(string)
+		c.RoleSecret = v
 	case "approle_mount":
-		c.AppRoleMount = value.(string)
+		c.AppRoleMount = v
 	default:
-		return fmt.Errorf("unknown configuration key: %s", key)
+		return &base.UnknownConfigKeyError{Key: key}
+	}
+	return nil
+}
+
+func (c *ConfigApiVaultAppRole) Validate() error {
+	if err := validateApiUrl("url", c.Url); err != nil {
+		return err
+	}
+	if strings.Trim(c.AppRoleMount, "/") == "" {
+		return &base.ConfigValidationError{Key: "approle_mount", Reason: "cannot be empty"}
 	}
 	return nil
 }
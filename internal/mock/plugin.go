@@ -14,12 +14,14 @@ import (
 	"sync"
 	// "time"
 	"context"
+	"encoding/json"
 
 	"github.com/hashicorp/vault/sdk/logical"
 
 	"github.com/gateplane-io/vault-plugins/internal/base"
 
 	"github.com/gateplane-io/vault-plugins/internal/utils"
+	"github.com/gateplane-io/vault-plugins/pkg/models"
 )
 
 type Backend struct {
@@ -40,13 +42,14 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 	}
 
 	b.ClaimArray = utils.NewCallbackArray(
-		(func(ctx context.Context, requ *logical.Request, ownerID string) (map[string]interface{}, error) { // Append
+		(func(ctx context.Context, requ *logical.Request, ownerID string, access json.RawMessage) (map[string]interface{}, error) { // Append
 
+			// Only the status is logged, as the AccessRequest holds the (decrypted) justification and comments
 			areq, err := b.GetRequest(ctx, requ, ownerID)
 			b.Logger().Warn(
 				"Function Claim",
 				"RequestorID", ownerID,
-				"AccessRequest", areq,
+				"Status", requestStatus(areq),
 				"Error", err,
 			)
 			ret := map[string]interface{}{
@@ -56,18 +59,51 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 		}),
 		(func(ctx context.Context, requ *logical.Request, ownerID string, internalData map[string]interface{}) error { // Remove
 
+			// Only the status is logged, as the AccessRequest holds the (decrypted) justification and comments
 			areq, err := b.GetRequest(ctx, requ, ownerID)
 			b.Logger().Warn(
 				"Function UnClaim",
 				"RequestorID", ownerID,
-				"AccessRequest", areq,
+				"Status", requestStatus(areq),
 				"InternalData", internalData,
 				"Error", err,
 			)
 			return err
 		}),
+		(func(ctx context.Context, requ *logical.Request, ownerID string, internalData map[string]interface{}) (utils.GrantState, error) { // Verify
+
+			// The mock grants nothing, so its access is in place while the claim is active
+			areq, err := b.GetRequest(ctx, requ, ownerID)
+			if err != nil || areq == nil || areq.Status != models.Active {
+				return utils.GrantAbsent, err
+			}
+			return utils.GrantPresent, nil
+		}),
+		(func(ctx context.Context, requ *logical.Request, ownerID string, access json.RawMessage) (map[string]interface{}, error) { // Snapshot
+
+			// The mock grants nothing, so nothing is held before the claim
+			return map[string]interface{}{}, nil
+		}),
+		(func(ctx context.Context, requ *logical.Request, ownerID string, access json.RawMessage, previous map[string]interface{}) error { // Rollback
+
+			b.Logger().Warn(
+				"Function Rollback",
+				"RequestorID", ownerID,
+				"Access", string(access),
+				"Previous", previous,
+			)
+			return nil
+		}),
 	)
 
 	b.Logger().Info("GatePlane Mock initialized with default configuration")
 	return nil
 }
+
+// requestStatus returns the status of 'areq' for logging ("" if it does not exist)
+func requestStatus(areq *base.AccessRequest) string {
+	if areq == nil {
+		return ""
+	}
+	return areq.Status.String()
+}
@@ -31,6 +31,7 @@ func PathConfigAccess(b *Backend) *framework.Path {
 				Description: "Okta GroupID (e.g: '00gpxxxxxxxxxxxxxxxx') to assign users to",
 				Required:    true,
 			},
+			base.ConfigDryRunKey: base.ConfigDryRunField,
 		},
 		Callbacks: map[logical.Operation]framework.OperationFunc{
 			logical.UpdateOperation: b.handleConfigAccessUpdate,
@@ -42,65 +43,127 @@ func PathConfigAccess(b *Backend) *framework.Path {
 		After the TTL passes the plugin reached to Okta API again and removes the user from the set Okta Group.
 
 		'okta_group_id' contains the Okta Group ID that will be joined (and retracted) when an AccessRequest is claimed.
+
+		'dry_run' validates the configuration and returns it, without storing it.
 		`,
 	}
 }
 
-func (b *Backend) handleConfigAccessUpdate(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
-	entityID := req.EntityID
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+// Section of '/config/bundle' for the access configuration
+func ConfigSectionAccess(b *Backend) base.ConfigSection {
+	return base.ConfigSection{
+		Key:    ConfigAccessKey,
+		Fields: PathConfigAccess(b).Fields,
+		Default: func() base.PluginConfig {
+			config := NewConfigAccess()
+			return &config
+		},
+		Response: func(config base.PluginConfig) (*logical.Response, error) {
+			return configAccessResponse(config.(*ConfigAccess))
+		},
+		Applied: b.configAccessApplied,
+	}
+}
 
-	config, err := base.GetConfiguration[*ConfigAccess](ctx, b.BaseBackend, req, "")
+// resolveGroupName returns the name of the Okta Group, or a warning if it cannot be retrieved
+func (b *Backend) resolveGroupName(ctx context.Context, storage logical.Storage, groupID string) (string, []string) {
+	groupName := ""
+	oktaClient, err := b.EnsureOktaAPI(ctx, storage)
+	if err == nil {
+		groupName, err = getGroupNameById(ctx, oktaClient, groupID)
+	}
 	if err != nil {
-		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+		return "", []string{"Could not retrieve Okta Group Name. Please check the Group ID (it is of the form '00gpxxxxxxxxxxxxxxxx') or Okta API configuration"}
 	}
+	return groupName, []string{}
+}
 
-	for key := range d.Raw {
-		value, ok := d.GetOk(key)
-		if !ok {
-			continue
-		}
-		b.Logger().Info("[*] Replacing configuration value",
-			"EntityID", entityID,
-			"ConfigKey", key,
-			// "OldValue", config
-			"NewValue", value,
-		)
-
-		err := config.SetConfigurationKey(key, value)
-		if err != nil {
-			return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrPermissionDenied
-		}
+// configAccessApplied sets the Okta Group Name of an access configuration applied
+// by '/config/bundle', a rollback or a ConfigProposal
+func (b *Backend) configAccessApplied(ctx context.Context, req *logical.Request, applied base.PluginConfig) []string {
+	groupID := applied.(*ConfigAccess).GroupID
+	groupName, warnings := b.resolveGroupName(ctx, req.Storage, groupID)
+
+	b.ConfigMutex.Lock()
+	defer b.ConfigMutex.Unlock()
+
+	config, err := base.GetConfigurationFromStorage[*ConfigAccess](ctx, b.BaseBackend, req.Storage, ConfigAccessKey)
+	if err != nil {
+		return append(warnings, fmt.Sprint(err))
+	}
+	// The configuration changed in the meantime
+	if config.GroupID != groupID || config.GroupName == groupName {
+		return warnings
+	}
+
+	// The Okta Group Name is derived from the Okta Group ID, so it is stored
+	// without recording a new version (the applied one is already recorded)
+	config.GroupName = groupName
+	if err := base.StoreConfigurationToStorage(ctx, b.BaseBackend, req.Storage, config, ConfigAccessKey); err != nil {
+		return append(warnings, fmt.Sprint(err))
 	}
+	return warnings
+}
+
+func (b *Backend) handleConfigAccessUpdate(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
 
-	err = b.EnsureOktaAPI(ctx, req.Storage)
+	// Resolve the Okta Group Name before taking the configuration lock,
+	// as it reaches the Okta API
 	warnings := []string{}
-	if err == nil {
-		oktaGroupName, err := getGroupNameById(ctx, b.OktaClient, config.GroupID)
-		if err != nil {
-			warnings = append(warnings, "Could not retrieve Okta Group Name. Please check the Group ID (it is of the form '00gpxxxxxxxxxxxxxxxx') or Okta API configuration")
-		}
-		config.GroupName = oktaGroupName
+	oktaGroupName := ""
+	groupID, groupIDSet := d.GetOk("okta_group_id")
+	if groupIDSet {
+		oktaGroupName, warnings = b.resolveGroupName(ctx, req.Storage, groupID.(string))
 	}
 
-	err = base.StoreConfiguration[*ConfigAccess](ctx, b.BaseBackend, req, config, "")
+	b.ConfigMutex.Lock()
+
+	config, err := base.GetConfiguration[*ConfigAccess](ctx, b.BaseBackend, req, "")
 	if err != nil {
+		b.ConfigMutex.Unlock()
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
+	// The Okta Group Name is not a field of the endpoint,
+	// it is set along with the Okta Group ID
+	if groupIDSet {
+		config.GroupName = oktaGroupName
+	}
+
+	proposal, stored, err := base.UpdateConfiguration(ctx, b.BaseBackend, req, d, config, "")
+	b.ConfigMutex.Unlock()
+	if err != nil {
+		return base.ConfigurationErrorResponse(err)
+	}
+	if proposal != nil {
+		return base.ConfigProposalResponse(proposal, req.EntityID)
+	}
+	if !stored {
+		resp, err := configAccessResponse(config)
+		if resp != nil {
+			resp.Warnings = warnings
+		}
+		return resp, err
+	}
+
+	// AccessRequests are locked outside of the configuration lock
+	warnings = append(warnings, b.AccessChanged(ctx, req)...)
 	return &logical.Response{Warnings: warnings}, nil
 }
 
 func (b *Backend) handleConfigAccessRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	b.ConfigMutex.RLock()
+	defer b.ConfigMutex.RUnlock()
 
 	config, err := base.GetConfiguration[*ConfigAccess](ctx, b.BaseBackend, req, "")
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
+	return configAccessResponse(config)
+}
+
+func configAccessResponse(config *ConfigAccess) (*logical.Response, error) {
 	responseObj := responses.ConfigAccessOktaGroupGate{
 		GroupID:   config.GroupID,
 		GroupName: config.GroupName,
@@ -36,8 +36,11 @@ func PathConfigApiOkta(b *Backend) *framework.Path {
 			},
 			"api_token": {
 				Type:        framework.TypeString,
-				Description: "The Okta SSWS API Token",
+				Description: "The Okta SSWS API Token (write-only)",
 				Required:    true,
+				DisplayAttrs: &framework.DisplayAttributes{
+					Sensitive: true,
+				},
 			},
 			"okta_entity_key": {
 				Type:        framework.TypeString,
@@ -49,6 +52,7 @@ func PathConfigApiOkta(b *Backend) *framework.Path {
 				Description: "The Mount Accessor that authenticates users to Vault through Okta",
 				Required:    false,
 			},
+			base.ConfigDryRunKey: base.ConfigDryRunField,
 		},
 		Callbacks: map[logical.Operation]framework.OperationFunc{
 			logical.UpdateOperation: b.handleConfigApiOktaUpdate,
@@ -62,41 +66,48 @@ func PathConfigApiOkta(b *Backend) *framework.Path {
 		'auth_mount_accessor': In case the user logs into Vault/OpenBao through Okta,
 		and the token's 'sub' value, containing the Okta User ID, is stored as Alias Name,
 		this field fetches the Okta ID.
+
+		'api_token' is write-only: it is seal-wrapped in storage, never logged
+		and reading this endpoint only reports whether it is set.
+
+		'dry_run' validates the configuration and returns it, without storing it or reaching Okta.
 		`,
 	}
 }
 
+// Section of '/config/bundle' for the Okta API configuration
+func ConfigSectionApiOkta(b *Backend) base.ConfigSection {
+	return base.ConfigSection{
+		Key:    ConfigAPIOktaKey,
+		Fields: PathConfigApiOkta(b).Fields,
+		Default: func() base.PluginConfig {
+			config := clientConfig.NewConfigApiOkta()
+			return &config
+		},
+		Response: func(config base.PluginConfig) (*logical.Response, error) {
+			return configApiOktaResponse(config.(*clientConfig.ConfigApiOkta))
+		},
+	}
+}
+
 func (b *Backend) handleConfigApiOktaUpdate(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
-	entityID := req.EntityID
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	b.ConfigMutex.Lock()
+	defer b.ConfigMutex.Unlock()
 
 	config, err := base.GetConfiguration[*clientConfig.ConfigApiOkta](ctx, b.BaseBackend, req, "")
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
-	for key := range d.Raw {
-		value, ok := d.GetOk(key)
-		if !ok {
-			continue
-		}
-		b.Logger().Info("[*] Replacing configuration value",
-			"EntityID", entityID,
-			"ConfigKey", key,
-			// "OldValue", config
-			"NewValue", value,
-		)
-
-		err := config.SetConfigurationKey(key, value)
-		if err != nil {
-			return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrPermissionDenied
-		}
-	}
-
-	err = base.StoreConfiguration[*clientConfig.ConfigApiOkta](ctx, b.BaseBackend, req, config, "")
+	proposal, stored, err := base.UpdateConfiguration(ctx, b.BaseBackend, req, d, config, "")
 	if err != nil {
-		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+		return base.ConfigurationErrorResponse(err)
+	}
+	if proposal != nil {
+		return base.ConfigProposalResponse(proposal, req.EntityID)
+	}
+	if !stored {
+		return configApiOktaResponse(config)
 	}
 
 	oktaClient, err := clients.NewOktaClient(config.OrgUrl, config.ApiToken)
@@ -105,20 +116,24 @@ func (b *Backend) handleConfigApiOktaUpdate(ctx context.Context, req *logical.Re
 			fmt.Sprintf("%s", err),
 		}}, nil
 	}
-	b.OktaClient = oktaClient
+	b.setOktaClient(oktaClient, config)
 
 	return &logical.Response{}, nil
 }
 
 func (b *Backend) handleConfigApiOktaRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	b.ConfigMutex.RLock()
+	defer b.ConfigMutex.RUnlock()
 
 	config, err := base.GetConfiguration[*clientConfig.ConfigApiOkta](ctx, b.BaseBackend, req, "")
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
+	return configApiOktaResponse(config)
+}
+
+func configApiOktaResponse(config *clientConfig.ConfigApiOkta) (*logical.Response, error) {
 	responseObj := responses.ConfigApiOktaResponse{
 		OrgUrl:                config.OrgUrl,
 		OktaOIDCMountAccessor: config.OktaOIDCMountAccessor,
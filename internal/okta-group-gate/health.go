@@ -0,0 +1,70 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package okta_group_gate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/internal/base"
+)
+
+// Health check of the authentication to the Okta API
+func HealthCheckOktaAPI(b *Backend) base.HealthCheck {
+	return base.HealthCheck{
+		Name: "okta_api",
+		Check: func(ctx context.Context, storage logical.Storage) (string, error) {
+			oktaClient, err := b.EnsureOktaAPI(ctx, storage)
+			if err != nil {
+				return "", err
+			}
+			// The user owning the API Token
+			user, _, err := oktaClient.UserAPI.GetUser(ctx, "me").Execute()
+			if err != nil {
+				return "", err
+			}
+			profile := user.GetProfile()
+			return fmt.Sprintf("Authenticated as '%s'", profile.GetLogin()), nil
+		},
+	}
+}
+
+// Health check of the existence of the Okta Group the Gate assigns users to
+func HealthCheckOktaGroup(b *Backend) base.HealthCheck {
+	return base.HealthCheck{
+		Name: "okta_group",
+		Check: func(ctx context.Context, storage logical.Storage) (string, error) {
+			b.ConfigMutex.RLock()
+			cfg, err := base.GetConfigurationFromStorage[*ConfigAccess](ctx,
+				b.BaseBackend, storage, ConfigAccessKey,
+			)
+			b.ConfigMutex.RUnlock()
+			if err != nil {
+				return "", err
+			}
+			if cfg.GroupID == "" {
+				return "", fmt.Errorf("no 'okta_group_id' is configured under '%s'", ConfigAccessKey)
+			}
+
+			oktaClient, err := b.EnsureOktaAPI(ctx, storage)
+			if err != nil {
+				return "", err
+			}
+			groupName, err := getGroupNameById(ctx, oktaClient, cfg.GroupID)
+			if err != nil {
+				return "", fmt.Errorf("could not read Okta Group '%s': %w", cfg.GroupID, err)
+			}
+			return fmt.Sprintf("Okta Group '%s' (%s) exists", groupName, cfg.GroupID), nil
+		},
+	}
+}
@@ -44,6 +44,25 @@ func oktaAddRemoveFromGroup(ctx context.Context, client *okta.APIClient, ttl tim
 	return nil
 }
 
+func oktaIsGroupMember(ctx context.Context, client *okta.APIClient, groupId string, userId string) (bool, error) {
+	groups, resp, err := client.UserAPI.ListUserGroups(ctx, userId).Execute()
+	for {
+		if err != nil {
+			return false, err
+		}
+		for _, group := range groups {
+			if group.GetId() == groupId {
+				return true, nil
+			}
+		}
+		if resp == nil || !resp.HasNextPage() {
+			return false, nil
+		}
+		groups = []okta.Group{}
+		resp, err = resp.Next(&groups)
+	}
+}
+
 func getGroupNameById(ctx context.Context, client *okta.APIClient, groupId string) (string, error) {
 
 	group, _, err := client.GroupAPI.GetGroup(ctx, groupId).Execute()
@@ -12,6 +12,8 @@ package okta_group_gate
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -25,13 +27,22 @@ import (
 )
 
 /* Storage Keys */
-const ConfigAPIOktaKey = "config/api/okta"
+const ConfigAPIOktaKey = base.ConfigAPIKeyPrefix + "okta"
 const ConfigAccessKey = "config/access"
 
+// ErrOktaUserNotFound is returned when an entity cannot be resolved to an Okta User
+var ErrOktaUserNotFound = errors.New("Could not retrieve Okta User ID from Vault/OpenBao Entity")
+
 type Backend struct {
 	*base.BaseBackend
-	Mutex      sync.Mutex
-	OktaClient *okta.APIClient
+	Mutex sync.Mutex
+	// ClientMutex guards 'OktaClient', which is shared by concurrent claims.
+	// It is never held while calling the Okta API.
+	ClientMutex sync.Mutex
+	OktaClient  *okta.APIClient
+	// The configuration 'OktaClient' was created with
+	oktaClientOrgUrl   string
+	oktaClientApiToken string
 }
 
 func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationRequest) error {
@@ -45,6 +56,15 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 		b.Logger().Error("Could not initialize Plugin Base")
 		return err
 	}
+	b.AccessConfigKey = ConfigAccessKey
+	b.ConfigSections = append(b.ConfigSections,
+		ConfigSectionAccess(b),
+		ConfigSectionApiOkta(b),
+	)
+	b.HealthChecks = append(b.HealthChecks,
+		HealthCheckOktaAPI(b),
+		HealthCheckOktaGroup(b),
+	)
 
 	configAccess := NewConfigAccess()
 	_, err = base.StoreConfigurationToStorageIfNotPresent[*ConfigAccess](ctx,
@@ -61,14 +81,15 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 	}
 
 	b.BaseBackend.ClaimArray = utils.NewCallbackArray(
-		(func(ctx context.Context, requ *logical.Request, ownerID string) (map[string]interface{}, error) { // Append
-			err := b.EnsureOktaAPI(ctx, req.Storage)
+		(func(ctx context.Context, requ *logical.Request, ownerID string, access json.RawMessage) (map[string]interface{}, error) { // Append
+			oktaClient, err := b.EnsureOktaAPI(ctx, req.Storage)
 			if err != nil {
 				return nil, err
 			}
 
-			cfg, err := base.GetConfigurationFromStorage[*ConfigAccess](ctx,
-				b.BaseBackend, req.Storage, ConfigAccessKey,
+			// Grant the access approved on the AccessRequest
+			cfg, err := base.GetAccessConfiguration[*ConfigAccess](ctx,
+				b.BaseBackend, req.Storage, access, ConfigAccessKey,
 			)
 			if err != nil {
 				return nil, err
@@ -91,7 +112,7 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 				return nil, err
 			}
 
-			err = oktaAddToGroup(ctx, b.OktaClient, groupID, oktaUserID)
+			err = oktaAddToGroup(ctx, oktaClient, groupID, oktaUserID)
 			if err != nil {
 				return nil, err
 			}
@@ -102,7 +123,7 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 			}, nil
 		}),
 		(func(ctx context.Context, requ *logical.Request, ownerID string, internalData map[string]interface{}) error { // Append
-			err := b.EnsureOktaAPI(ctx, req.Storage)
+			oktaClient, err := b.EnsureOktaAPI(ctx, req.Storage)
 			if err != nil {
 				return err
 			}
@@ -110,12 +131,103 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 			groupID := internalData["okta_group_id"].(string)
 			oktaUserID := internalData["okta_user_id"].(string)
 
-			err = oktaRemoveFromGroup(ctx, b.OktaClient, groupID, oktaUserID)
+			err = oktaRemoveFromGroup(ctx, oktaClient, groupID, oktaUserID)
 			if err != nil {
 				return err
 			}
 			return err
 		}),
+		(func(ctx context.Context, requ *logical.Request, ownerID string, internalData map[string]interface{}) (utils.GrantState, error) { // Verify
+			oktaClient, err := b.EnsureOktaAPI(ctx, req.Storage)
+			if err != nil {
+				return utils.GrantAbsent, err
+			}
+
+			groupID, _ := internalData["okta_group_id"].(string)
+			oktaUserID, _ := internalData["okta_user_id"].(string)
+			if groupID == "" || oktaUserID == "" {
+				return utils.GrantAbsent, fmt.Errorf("Claim data has no Okta Group or User")
+			}
+
+			member, err := oktaIsGroupMember(ctx, oktaClient, groupID, oktaUserID)
+			if err != nil {
+				return utils.GrantAbsent, err
+			}
+			if member {
+				return utils.GrantPresent, nil
+			}
+			return utils.GrantAbsent, nil
+		}),
+		(func(ctx context.Context, requ *logical.Request, ownerID string, access json.RawMessage) (map[string]interface{}, error) { // Snapshot
+			oktaClient, err := b.EnsureOktaAPI(ctx, req.Storage)
+			if err != nil {
+				return nil, err
+			}
+
+			cfg, err := base.GetAccessConfiguration[*ConfigAccess](ctx,
+				b.BaseBackend, req.Storage, access, ConfigAccessKey,
+			)
+			if err != nil {
+				return nil, err
+			}
+			oktaConfig, err := base.GetConfigurationFromStorage[*clientConfig.ConfigApiOkta](ctx,
+				b.BaseBackend, req.Storage, ConfigAPIOktaKey,
+			)
+			if err != nil {
+				return nil, err
+			}
+			oktaUserID, err := b.GetOktaUserID(ctx, oktaConfig, ownerID)
+			if errors.Is(err, ErrOktaUserNotFound) {
+				// No Okta User can be added to the group
+				return map[string]interface{}{}, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			member, err := oktaIsGroupMember(ctx, oktaClient, cfg.GroupID, oktaUserID)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{
+				"okta_group_id":        cfg.GroupID,
+				"okta_user_id":         oktaUserID,
+				"okta_previous_member": member,
+			}, nil
+		}),
+		(func(ctx context.Context, requ *logical.Request, ownerID string, access json.RawMessage, previous map[string]interface{}) error { // Rollback
+			if previous == nil {
+				// The Okta User is added with a single call, so a failed Append granted nothing,
+				// and the Okta User may have been a member of the group before the claim
+				b.Logger().Warn("[!] Nothing to roll back, as the group membership before the claim is unknown",
+					"EntityID", ownerID,
+				)
+				return nil
+			}
+			groupID, _ := previous["okta_group_id"].(string)
+			oktaUserID, _ := previous["okta_user_id"].(string)
+			if groupID == "" || oktaUserID == "" {
+				// No Okta User could have been added to the group
+				b.Logger().Warn("[!] Nothing to roll back, as the entity has no Okta User",
+					"EntityID", ownerID,
+				)
+				return nil
+			}
+			if member, _ := previous["okta_previous_member"].(bool); member {
+				// The membership held before the claim is kept
+				return nil
+			}
+
+			oktaClient, err := b.EnsureOktaAPI(ctx, req.Storage)
+			if err != nil {
+				return err
+			}
+			member, err := oktaIsGroupMember(ctx, oktaClient, groupID, oktaUserID)
+			if err != nil || !member {
+				return err
+			}
+			return oktaRemoveFromGroup(ctx, oktaClient, groupID, oktaUserID)
+		}),
 	)
 	b.Logger().Info("GatePlane Okta Group Gate initialized with default configuration",
 		"path", ConfigAPIOktaKey,
@@ -125,24 +237,43 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 	return nil
 }
 
-func (b *Backend) EnsureOktaAPI(ctx context.Context, storage logical.Storage) error {
+// EnsureOktaAPI returns an Okta API client created from the stored configuration.
+func (b *Backend) EnsureOktaAPI(ctx context.Context, storage logical.Storage) (*okta.APIClient, error) {
 
 	cfg, err := base.GetConfigurationFromStorage[*clientConfig.ConfigApiOkta](ctx,
 		b.BaseBackend, storage, ConfigAPIOktaKey,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if cfg.ApiToken != "" {
-		oktaClient, err := clients.NewOktaClient(cfg.OrgUrl, cfg.ApiToken)
+	// The cached client is reused while the configuration is unchanged
+	b.ClientMutex.Lock()
+	oktaClient := b.OktaClient
+	current := oktaClient != nil && b.oktaClientOrgUrl == cfg.OrgUrl && b.oktaClientApiToken == cfg.ApiToken
+	b.ClientMutex.Unlock()
+
+	if !current && cfg.ApiToken != "" {
+		oktaClient, err = clients.NewOktaClient(cfg.OrgUrl, cfg.ApiToken)
 		if err != nil {
 			b.Logger().Error("[-] Could not create Okta Client")
-			return err
+			return nil, err
 		}
-		b.OktaClient = oktaClient
+		b.setOktaClient(oktaClient, cfg)
 	}
-	return nil
+	if oktaClient == nil {
+		return nil, fmt.Errorf("Okta API is not configured")
+	}
+	return oktaClient, nil
+}
+
+// setOktaClient replaces the Okta API client with 'oktaClient', created with 'cfg'
+func (b *Backend) setOktaClient(oktaClient *okta.APIClient, cfg *clientConfig.ConfigApiOkta) {
+	b.ClientMutex.Lock()
+	defer b.ClientMutex.Unlock()
+	b.OktaClient = oktaClient
+	b.oktaClientOrgUrl = cfg.OrgUrl
+	b.oktaClientApiToken = cfg.ApiToken
 }
 
 func (b *Backend) GetOktaUserID(ctx context.Context, config *clientConfig.ConfigApiOkta, entityID string) (string, error) {
@@ -152,6 +283,10 @@ func (b *Backend) GetOktaUserID(ctx context.Context, config *clientConfig.Config
 	if err != nil {
 		return "", err
 	}
+	if entity == nil {
+		// The entity was deleted
+		return "", fmt.Errorf("entity %s not found: %w", entityID, ErrOktaUserNotFound)
+	}
 
 	entityMeta := entity.GetMetadata()
 	oktaUserId, exists := entityMeta[config.OktaEntityKey]
@@ -175,5 +310,5 @@ func (b *Backend) GetOktaUserID(ctx context.Context, config *clientConfig.Config
 		"OktaEntityKey", config.OktaEntityKey,
 	)
 
-	return "", fmt.Errorf("Could not retrieve Okta User ID from Vault/OpenBao Entity")
+	return "", ErrOktaUserNotFound
 }
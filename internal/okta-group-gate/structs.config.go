@@ -11,7 +11,9 @@
 package okta_group_gate
 
 import (
-	"fmt"
+	"strings"
+
+	"github.com/gateplane-io/vault-plugins/internal/base"
 )
 
 type ConfigAccess struct {
@@ -25,10 +27,17 @@ func (c *ConfigAccess) SetConfigurationKey(key string, value interface{}) error
 		if v, ok := value.(string); ok {
 			c.GroupID = v
 		} else {
-			return fmt.Errorf("invalid type for 'okta_group_id', expected string")
+			return &base.ConfigTypeError{Key: key, Expected: "string"}
 		}
 	default:
-		return fmt.Errorf("unknown configuration key: %s", key)
+		return &base.UnknownConfigKeyError{Key: key}
+	}
+	return nil
+}
+
+func (c *ConfigAccess) Validate() error {
+	if strings.TrimSpace(c.GroupID) == "" {
+		return &base.ConfigValidationError{Key: "okta_group_id", Reason: "cannot be empty"}
 	}
 	return nil
 }
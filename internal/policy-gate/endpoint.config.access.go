@@ -31,6 +31,7 @@ func PathConfigAccess(b *Backend) *framework.Path {
 				Description: "The Vault/OpenBao policies to be assigned to approved requestors",
 				Required:    true,
 			},
+			base.ConfigDryRunKey: base.ConfigDryRunField,
 		},
 		Callbacks: map[logical.Operation]framework.OperationFunc{
 			logical.UpdateOperation: b.handleConfigAccessUpdate,
@@ -41,55 +42,66 @@ func PathConfigAccess(b *Backend) *framework.Path {
 		HelpDescription: `This endpoint sets the Vault/OpenBao policies to be conditionally assigned to the requesting Entity.
 
 		'policies' contains a list of Vault/OpenBao Policies that will be assigned (and retracted) when an AccessRequest is claimed.
+		The 'root' policy cannot be assigned.
+
+		'dry_run' validates the configuration and returns it, without storing it.
 		`,
 	}
 }
 
+// Section of '/config/bundle' for the access configuration
+func ConfigSectionAccess(b *Backend) base.ConfigSection {
+	return base.ConfigSection{
+		Key:    ConfigAccessKey,
+		Fields: PathConfigAccess(b).Fields,
+		Default: func() base.PluginConfig {
+			config := NewConfigAccess()
+			return &config
+		},
+		Response: func(config base.PluginConfig) (*logical.Response, error) {
+			return configAccessResponse(config.(*ConfigAccess))
+		},
+	}
+}
+
 func (b *Backend) handleConfigAccessUpdate(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
-	entityID := req.EntityID
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	b.ConfigMutex.Lock()
 
 	config, err := base.GetConfiguration[*ConfigAccess](ctx, b.BaseBackend, req, "")
 	if err != nil {
+		b.ConfigMutex.Unlock()
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
-	for key := range d.Raw {
-		value, ok := d.GetOk(key)
-		if !ok {
-			continue
-		}
-		b.Logger().Info("[*] Replacing configuration value",
-			"EntityID", entityID,
-			"ConfigKey", key,
-			// "OldValue", config
-			"NewValue", value,
-		)
-
-		err := config.SetConfigurationKey(key, value)
-		if err != nil {
-			return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrPermissionDenied
-		}
-	}
-
-	err = base.StoreConfiguration[*ConfigAccess](ctx, b.BaseBackend, req, config, "")
+	proposal, stored, err := base.UpdateConfiguration(ctx, b.BaseBackend, req, d, config, "")
+	b.ConfigMutex.Unlock()
 	if err != nil {
-		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+		return base.ConfigurationErrorResponse(err)
+	}
+	if proposal != nil {
+		return base.ConfigProposalResponse(proposal, req.EntityID)
+	}
+	if !stored {
+		return configAccessResponse(config)
 	}
 
-	return &logical.Response{}, nil
+	// AccessRequests are locked outside of the configuration lock
+	return &logical.Response{Warnings: b.AccessChanged(ctx, req)}, nil
 }
 
 func (b *Backend) handleConfigAccessRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	b.ConfigMutex.RLock()
+	defer b.ConfigMutex.RUnlock()
 
 	config, err := base.GetConfiguration[*ConfigAccess](ctx, b.BaseBackend, req, "")
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
+	return configAccessResponse(config)
+}
+
+func configAccessResponse(config *ConfigAccess) (*logical.Response, error) {
 	responseObj := responses.ConfigAccessPolicyGate{
 		Policies: config.Policies,
 	}
@@ -13,6 +13,7 @@ package policy_gate
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/vault/sdk/framework"
 	"github.com/hashicorp/vault/sdk/logical"
@@ -41,14 +42,18 @@ func PathConfigApiVault(b *Backend) *framework.Path {
 			},
 			"role_secret": {
 				Type:        framework.TypeString,
-				Description: "The AppRole Secret to authenticate with",
+				Description: "The AppRole Secret to authenticate with (write-only)",
 				Required:    false,
+				DisplayAttrs: &framework.DisplayAttributes{
+					Sensitive: true,
+				},
 			},
 			"approle_mount": {
 				Type:        framework.TypeString,
 				Description: "The Vault Auth Mount for AppRole",
 				Required:    false,
 			},
+			base.ConfigDryRunKey: base.ConfigDryRunField,
 		},
 		Callbacks: map[logical.Operation]framework.OperationFunc{
 			logical.UpdateOperation: b.handleConfigApiVaultUpdate,
@@ -68,63 +73,93 @@ func PathConfigApiVault(b *Backend) *framework.Path {
 		The provided AppRole must be able to
 		'read' and 'update' the 'identity/entity/id/*',
 		and 'read' the 'auth/token/lookup-self' paths.
+
+		'role_secret' is write-only: it is seal-wrapped in storage, never logged
+		and reading this endpoint only reports whether it is set.
+
+		'dry_run' validates the configuration and returns it, without storing it or logging in.
 		`,
 	}
 }
 
+// Section of '/config/bundle' for the Vault/OpenBao API configuration
+func ConfigSectionApiVault(b *Backend) base.ConfigSection {
+	return base.ConfigSection{
+		Key:    ConfigAPIVaultKey,
+		Fields: PathConfigApiVault(b).Fields,
+		Default: func() base.PluginConfig {
+			config := clientConfig.NewConfigApiVaultAppRole()
+			return &config
+		},
+		Response: func(config base.PluginConfig) (*logical.Response, error) {
+			return configApiVaultResponse(config.(*clientConfig.ConfigApiVaultAppRole))
+		},
+		Applied: func(ctx context.Context, req *logical.Request, config base.PluginConfig) []string {
+			if err := b.reloadVaultClient(ctx, config.(*clientConfig.ConfigApiVaultAppRole)); err != nil {
+				return []string{fmt.Sprintf("'%s': %s", ConfigAPIVaultKey, err)}
+			}
+			return nil
+		},
+	}
+}
+
 func (b *Backend) handleConfigApiVaultUpdate(ctx context.Context, req *logical.Request, d *framework.FieldData) (*logical.Response, error) {
-	entityID := req.EntityID
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	b.ConfigMutex.Lock()
 
 	config, err := base.GetConfiguration[*clientConfig.ConfigApiVaultAppRole](ctx, b.BaseBackend, req, "")
 	if err != nil {
+		b.ConfigMutex.Unlock()
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
-	for key := range d.Raw {
-		value, ok := d.GetOk(key)
-		if !ok {
-			continue
-		}
-		b.Logger().Info("[*] Replacing configuration value",
-			"EntityID", entityID,
-			"ConfigKey", key,
-			// "OldValue", config
-			"NewValue", value,
-		)
-
-		err := config.SetConfigurationKey(key, value)
-		if err != nil {
-			return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrPermissionDenied
-		}
-	}
-
-	err = base.StoreConfiguration[*clientConfig.ConfigApiVaultAppRole](ctx, b.BaseBackend, req, config, "")
+	proposal, stored, err := base.UpdateConfiguration(ctx, b.BaseBackend, req, d, config, "")
+	b.ConfigMutex.Unlock()
 	if err != nil {
-		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
+		return base.ConfigurationErrorResponse(err)
+	}
+	if proposal != nil {
+		return base.ConfigProposalResponse(proposal, req.EntityID)
+	}
+	if !stored {
+		return configApiVaultResponse(config)
 	}
 
-	vaultClient, err := clients.NewVaultAppRoleClient(ctx, *config, nil)
-	if err != nil {
+	// Log in with the new credentials outside of the configuration lock
+	if err := b.reloadVaultClient(ctx, config); err != nil {
 		return &logical.Response{Warnings: []string{
 			fmt.Sprintf("%s", err),
 		}}, nil
 	}
-	b.VaultClient = vaultClient
 
 	return &logical.Response{}, nil
 }
 
+// reloadVaultClient replaces the Vault API client with one logged in with 'config'
+func (b *Backend) reloadVaultClient(ctx context.Context, config *clientConfig.ConfigApiVaultAppRole) error {
+	vaultClient, err := clients.NewVaultAppRoleClient(ctx, *config, nil)
+	if err != nil {
+		return err
+	}
+	b.ClientMutex.Lock()
+	b.VaultClient = vaultClient
+	b.vaultClientCheckedAt = time.Now()
+	b.ClientMutex.Unlock()
+	return nil
+}
+
 func (b *Backend) handleConfigApiVaultRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
-	b.BaseMutex.Lock()
-	defer b.BaseMutex.Unlock()
+	b.ConfigMutex.RLock()
+	defer b.ConfigMutex.RUnlock()
 
 	config, err := base.GetConfiguration[*clientConfig.ConfigApiVaultAppRole](ctx, b.BaseBackend, req, "")
 	if err != nil {
 		return logical.ErrorResponse(fmt.Sprint(err)), logical.ErrMissingRequiredState
 	}
 
+	return configApiVaultResponse(config)
+}
+
+func configApiVaultResponse(config *clientConfig.ConfigApiVaultAppRole) (*logical.Response, error) {
 	responseObj := responses.ConfigApiVaultResponse{
 		Url:           config.Url,
 		RoleID:        config.RoleID,
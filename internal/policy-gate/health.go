@@ -0,0 +1,106 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package policy_gate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/gateplane-io/vault-plugins/internal/base"
+)
+
+// Path the Vault API client changes the policies of Entities under
+const entityCapabilitiesPath = "identity/entity/id/*"
+
+// Health check of the authentication to the Vault API
+func HealthCheckVaultAPI(b *Backend) base.HealthCheck {
+	return base.HealthCheck{
+		Name: "vault_api",
+		Check: func(ctx context.Context, storage logical.Storage) (string, error) {
+			vaultClient, err := b.EnsureVaultAPI(ctx, storage)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Authenticated to '%s'", vaultClient.Address()), nil
+		},
+	}
+}
+
+// Health check of the capabilities required to grant policies to Entities
+func HealthCheckVaultCapabilities(b *Backend) base.HealthCheck {
+	return base.HealthCheck{
+		Name: "vault_capabilities",
+		Check: func(ctx context.Context, storage logical.Storage) (string, error) {
+			vaultClient, err := b.EnsureVaultAPI(ctx, storage)
+			if err != nil {
+				return "", err
+			}
+			capabilities, err := vaultClient.Sys().CapabilitiesSelfWithContext(ctx, entityCapabilitiesPath)
+			if err != nil {
+				return "", err
+			}
+			if slices.Contains(capabilities, "root") {
+				return fmt.Sprintf("'root' on '%s'", entityCapabilitiesPath), nil
+			}
+			for _, required := range []string{"read", "update"} {
+				if !slices.Contains(capabilities, required) {
+					return "", fmt.Errorf("missing '%s' capability on '%s' (has: %s)",
+						required, entityCapabilitiesPath, strings.Join(capabilities, ", "),
+					)
+				}
+			}
+			return fmt.Sprintf("'%s' on '%s'", strings.Join(capabilities, ", "), entityCapabilitiesPath), nil
+		},
+	}
+}
+
+// Health check of the existence of the policies granted by the Gate
+func HealthCheckPolicies(b *Backend) base.HealthCheck {
+	return base.HealthCheck{
+		Name: "policies",
+		Check: func(ctx context.Context, storage logical.Storage) (string, error) {
+			b.ConfigMutex.RLock()
+			cfg, err := base.GetConfigurationFromStorage[*ConfigAccess](ctx,
+				b.BaseBackend, storage, ConfigAccessKey,
+			)
+			b.ConfigMutex.RUnlock()
+			if err != nil {
+				return "", err
+			}
+			if len(cfg.Policies) == 0 {
+				return "", fmt.Errorf("no policies are configured under '%s'", ConfigAccessKey)
+			}
+
+			vaultClient, err := b.EnsureVaultAPI(ctx, storage)
+			if err != nil {
+				return "", err
+			}
+			missing := []string{}
+			for _, policy := range cfg.Policies {
+				rules, err := vaultClient.Sys().GetPolicyWithContext(ctx, policy)
+				if err != nil {
+					return "", fmt.Errorf("could not read policy '%s': %w", policy, err)
+				}
+				if rules == "" {
+					missing = append(missing, policy)
+				}
+			}
+			if len(missing) > 0 {
+				return "", fmt.Errorf("policies do not exist: %s", strings.Join(missing, ", "))
+			}
+			return fmt.Sprintf("Policies exist: %s", strings.Join(cfg.Policies, ", ")), nil
+		},
+	}
+}
@@ -10,6 +10,12 @@
 
 package policy_gate
 
+import (
+	"fmt"
+
+	"github.com/gateplane-io/vault-plugins/internal/utils"
+)
+
 func Subtract[T comparable](a, b []T) []T {
 	if len(b) == 0 {
 		return append([]T(nil), a...)
@@ -36,3 +42,38 @@ func InterfaceSliceToStringsStrict(in []interface{}) []string {
 	}
 	return out
 }
+
+// PoliciesGrantState returns how many of the 'granted' policies are in 'entityPolicies'
+func PoliciesGrantState(entityPolicies []string, granted []string) utils.GrantState {
+	found := len(granted) - len(Subtract[string](granted, entityPolicies))
+	switch {
+	case found == 0:
+		return utils.GrantAbsent
+	case found < len(granted):
+		return utils.GrantPartial
+	}
+	return utils.GrantPresent
+}
+
+// ClaimGrantedPolicies returns the policies added to the entity by a claim,
+// which are the ones of the claim that the entity did not have before it
+func ClaimGrantedPolicies(internalData map[string]interface{}) ([]string, error) {
+	newPolicies, ok := claimPolicies(internalData, "new_policies")
+	if !ok {
+		return nil, fmt.Errorf("Claim data has no granted policies")
+	}
+	previousPolicies, _ := claimPolicies(internalData, "previous_policies")
+	return Subtract[string](newPolicies, previousPolicies), nil
+}
+
+// claimPolicies reads policies from claim data, as returned by Append or Snapshot ([]string)
+// or after being stored ([]interface{})
+func claimPolicies(internalData map[string]interface{}, key string) ([]string, bool) {
+	switch v := internalData[key].(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		return InterfaceSliceToStringsStrict(v), true
+	}
+	return nil, false
+}
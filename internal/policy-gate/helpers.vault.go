@@ -15,6 +15,8 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/vault/api"
+
+	"github.com/gateplane-io/vault-plugins/internal/utils"
 )
 
 // GetEntityPolicies returns the policies assigned to a Vault identity entity.
@@ -162,3 +164,16 @@ func SetEntityPolicies(ctx context.Context, client *api.Client, entityID string,
 	}
 	return nil
 }
+
+// RemoveEntityPolicies removes the provided policies from the current direct policies of the given entity,
+// keeping any other policy assigned to it.
+func RemoveEntityPolicies(ctx context.Context, client *api.Client, entityID string, policiesToRemove []string) error {
+	existing, err := GetEntityPolicies(ctx, client, entityID)
+	if err != nil {
+		return fmt.Errorf("getting existing policies for entity %s: %w", entityID, err)
+	}
+	if PoliciesGrantState(existing, policiesToRemove) == utils.GrantAbsent {
+		return nil
+	}
+	return SetEntityPolicies(ctx, client, entityID, Subtract[string](existing, policiesToRemove))
+}
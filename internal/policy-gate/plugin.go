@@ -12,7 +12,9 @@ package policy_gate
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/vault/sdk/logical"
 
@@ -25,15 +27,23 @@ import (
 )
 
 /* Storage Keys */
-const ConfigAPIVaultKey = "config/api/vault"
+const ConfigAPIVaultKey = base.ConfigAPIKeyPrefix + "vault"
 const ConfigAccessKey = "config/access"
 
 type Backend struct {
 	*base.BaseBackend
-	Mutex       sync.Mutex
+	Mutex sync.Mutex
+	// ClientMutex guards 'VaultClient', which is shared by concurrent claims.
+	// It is never held while calling the Vault API.
+	ClientMutex sync.Mutex
 	VaultClient *vault.Client
+	// When the authentication of 'VaultClient' was last checked
+	vaultClientCheckedAt time.Time
 }
 
+// The authentication of the Vault API client is checked again after this long
+const VaultClientCheckInterval = 1 * time.Minute
+
 func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationRequest) error {
 	b.Mutex.Lock()
 	defer b.Mutex.Unlock()
@@ -45,6 +55,16 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 		b.Logger().Error("Could not initialize Plugin Base")
 		return err
 	}
+	b.AccessConfigKey = ConfigAccessKey
+	b.ConfigSections = append(b.ConfigSections,
+		ConfigSectionAccess(b),
+		ConfigSectionApiVault(b),
+	)
+	b.HealthChecks = append(b.HealthChecks,
+		HealthCheckVaultAPI(b),
+		HealthCheckVaultCapabilities(b),
+		HealthCheckPolicies(b),
+	)
 
 	configAccess := NewConfigAccess()
 	_, err = base.StoreConfigurationToStorageIfNotPresent[*ConfigAccess](ctx,
@@ -60,7 +80,7 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 		return err
 	}
 	if exists {
-		err = b.EnsureVaultAPI(ctx, req.Storage)
+		_, err = b.EnsureVaultAPI(ctx, req.Storage)
 		if err != nil {
 			b.Logger().Error("[-] Could not initialize Vault API with existing Configuration",
 				"path", ConfigAPIVaultKey,
@@ -72,21 +92,22 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 	}
 
 	b.BaseBackend.ClaimArray = utils.NewCallbackArray(
-		(func(ctx context.Context, requ *logical.Request, ownerID string) (map[string]interface{}, error) { // Append
-			err := b.EnsureVaultAPI(ctx, req.Storage)
+		(func(ctx context.Context, requ *logical.Request, ownerID string, access json.RawMessage) (map[string]interface{}, error) { // Append
+			vaultClient, err := b.EnsureVaultAPI(ctx, req.Storage)
 			if err != nil {
 				return nil, err
 			}
 
-			cfg, err := base.GetConfigurationFromStorage[*ConfigAccess](ctx,
-				b.BaseBackend, req.Storage, ConfigAccessKey,
+			// Grant the access approved on the AccessRequest
+			cfg, err := base.GetAccessConfiguration[*ConfigAccess](ctx,
+				b.BaseBackend, req.Storage, access, ConfigAccessKey,
 			)
 			if err != nil {
 				return nil, err
 			}
 
 			newPolicies := cfg.Policies //[]string{"test-pgate"} // to be fetched from config
-			existingPolicies, err := AddPoliciesToEntity(ctx, b.VaultClient, ownerID, newPolicies)
+			existingPolicies, err := AddPoliciesToEntity(ctx, vaultClient, ownerID, newPolicies)
 			if err != nil {
 				return nil, err
 			}
@@ -96,19 +117,76 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 				"new_policies":      newPolicies,
 			}, nil
 		}),
-		(func(ctx context.Context, requ *logical.Request, ownerID string, internalData map[string]interface{}) error { // Append
-			err := b.EnsureVaultAPI(ctx, req.Storage)
+		(func(ctx context.Context, requ *logical.Request, ownerID string, internalData map[string]interface{}) error { // Remove
+			vaultClient, err := b.EnsureVaultAPI(ctx, req.Storage)
 			if err != nil {
 				return err
 			}
 
-			policies := Subtract[string](
-				InterfaceSliceToStringsStrict(internalData["previous_policies"].([]interface{})),
-				InterfaceSliceToStringsStrict(internalData["new_policies"].([]interface{})),
-			)
-			err = SetEntityPolicies(ctx, b.VaultClient, ownerID, policies)
+			// Policies assigned to the entity since the claim are kept
+			granted, err := ClaimGrantedPolicies(internalData)
+			if err != nil {
+				return err
+			}
+			return RemoveEntityPolicies(ctx, vaultClient, ownerID, granted)
+		}),
+		(func(ctx context.Context, requ *logical.Request, ownerID string, internalData map[string]interface{}) (utils.GrantState, error) { // Verify
+			vaultClient, err := b.EnsureVaultAPI(ctx, req.Storage)
+			if err != nil {
+				return utils.GrantAbsent, err
+			}
 
-			return err
+			granted, err := ClaimGrantedPolicies(internalData)
+			if err != nil {
+				return utils.GrantAbsent, err
+			}
+			if len(granted) == 0 {
+				// The entity already had all the policies of the claim
+				granted, _ = claimPolicies(internalData, "new_policies")
+			}
+			entityPolicies, err := GetEntityPolicies(ctx, vaultClient, ownerID)
+			if err != nil {
+				return utils.GrantAbsent, err
+			}
+			return PoliciesGrantState(entityPolicies, granted), nil
+		}),
+		(func(ctx context.Context, requ *logical.Request, ownerID string, access json.RawMessage) (map[string]interface{}, error) { // Snapshot
+			vaultClient, err := b.EnsureVaultAPI(ctx, req.Storage)
+			if err != nil {
+				return nil, err
+			}
+
+			existingPolicies, err := GetEntityPolicies(ctx, vaultClient, ownerID)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{
+				"previous_policies": existingPolicies,
+			}, nil
+		}),
+		(func(ctx context.Context, requ *logical.Request, ownerID string, access json.RawMessage, previous map[string]interface{}) error { // Rollback
+			previousPolicies, ok := claimPolicies(previous, "previous_policies")
+			if !ok {
+				// Policies are added with a single write, so a failed Append granted nothing,
+				// and the policies of the access may have been held before the claim
+				b.Logger().Warn("[!] Nothing to roll back, as the policies before the claim are unknown",
+					"EntityID", ownerID,
+				)
+				return nil
+			}
+
+			vaultClient, err := b.EnsureVaultAPI(ctx, req.Storage)
+			if err != nil {
+				return err
+			}
+			cfg, err := base.GetAccessConfiguration[*ConfigAccess](ctx,
+				b.BaseBackend, req.Storage, access, ConfigAccessKey,
+			)
+			if err != nil {
+				return err
+			}
+			// Policies held before the claim are kept
+			return RemoveEntityPolicies(ctx, vaultClient, ownerID, Subtract[string](cfg.Policies, previousPolicies))
 		}),
 	)
 	b.Logger().Info("GatePlane Policy Gate initialized with default configuration",
@@ -120,34 +198,50 @@ func (b *Backend) Initialize(ctx context.Context, req *logical.InitializationReq
 	return nil
 }
 
-func (b *Backend) EnsureVaultAPI(ctx context.Context, storage logical.Storage) error {
+// EnsureVaultAPI returns an authenticated Vault API client,
+// creating it or logging in again if needed.
+func (b *Backend) EnsureVaultAPI(ctx context.Context, storage logical.Storage) (*vault.Client, error) {
 	cfg, err := base.GetConfigurationFromStorage[*clientConfig.ConfigApiVaultAppRole](ctx,
 		b.BaseBackend, storage, ConfigAPIVaultKey,
 	)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	b.ClientMutex.Lock()
+	cached, checkedAt := b.VaultClient, b.vaultClientCheckedAt
+	b.ClientMutex.Unlock()
+
+	// The cached client is reused while its authentication was checked recently
+	if cached != nil && time.Since(checkedAt) < VaultClientCheckInterval {
+		return cached, nil
 	}
 
-	if b.VaultClient == nil {
-		client, err := clients.NewVaultAppRoleClient(ctx, *cfg, nil)
+	client := cached
+	if client == nil {
+		client, err = clients.NewVaultAppRoleClient(ctx, *cfg, nil)
 		if err != nil {
 			b.Logger().Error("[-] Could not create and authenticate the Vault API client",
 				"error", err,
 			)
-			return err
+			return nil, err
 		}
-		b.VaultClient = client
-		return nil
-	}
-
-	if err := clients.EnsureAuthenticationVault(ctx,
-		b.VaultClient, cfg.RoleID, cfg.RoleSecret, cfg.AppRoleMount,
+	} else if err := clients.EnsureAuthenticationVault(ctx,
+		client, cfg.RoleID, cfg.RoleSecret, cfg.AppRoleMount,
 	); err != nil {
 		b.Logger().Error("[-] Could not ensure authentication to the Vault API",
 			"error", err,
 		)
-		return err
+		return nil, err
 	}
 
-	return nil
+	b.ClientMutex.Lock()
+	defer b.ClientMutex.Unlock()
+	if b.VaultClient != cached {
+		// Replaced meanwhile (e.g.: by a configuration update), so the newer client is used
+		return b.VaultClient, nil
+	}
+	b.VaultClient = client
+	b.vaultClientCheckedAt = time.Now()
+	return client, nil
 }
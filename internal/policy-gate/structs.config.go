@@ -11,7 +11,9 @@
 package policy_gate
 
 import (
-	"fmt"
+	"strings"
+
+	"github.com/gateplane-io/vault-plugins/internal/base"
 )
 
 type ConfigAccess struct {
@@ -24,10 +26,23 @@ func (c *ConfigAccess) SetConfigurationKey(key string, value interface{}) error
 		if v, ok := value.([]string); ok {
 			c.Policies = v
 		} else {
-			return fmt.Errorf("invalid type for policies, expected []string")
+			return &base.ConfigTypeError{Key: key, Expected: "list of strings"}
 		}
 	default:
-		return fmt.Errorf("unknown configuration key: %s", key)
+		return &base.UnknownConfigKeyError{Key: key}
+	}
+	return nil
+}
+
+func (c *ConfigAccess) Validate() error {
+	for _, policy := range c.Policies {
+		if strings.TrimSpace(policy) == "" {
+			return &base.ConfigValidationError{Key: "policies", Reason: "cannot contain empty policy names"}
+		}
+		// Assigning 'root' would let any approved requestor take over Vault/OpenBao
+		if policy == "root" {
+			return &base.ConfigValidationError{Key: "policies", Reason: "cannot contain the 'root' policy"}
+		}
 	}
 	return nil
 }
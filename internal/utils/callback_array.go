@@ -12,35 +12,72 @@ package utils
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/vault/sdk/logical"
 )
 
+// GrantState is the state of the access of an element, as found by the Verify callback
+type GrantState int
+
+const (
+	// None of the access is in place
+	GrantAbsent GrantState = iota
+	// Some of the access is in place
+	GrantPartial
+	// All of the access is in place
+	GrantPresent
+)
+
+func (s GrantState) String() string {
+	switch s {
+	case GrantAbsent:
+		return "absent"
+	case GrantPartial:
+		return "partial"
+	case GrantPresent:
+		return "present"
+	}
+	return "unknown"
+}
+
 type CallbackArray struct {
-	onAppend func(context.Context, *logical.Request, string) (map[string]interface{}, error)
-	onRemove func(context.Context, *logical.Request, string, map[string]interface{}) error
+	onAppend   func(context.Context, *logical.Request, string, json.RawMessage) (map[string]interface{}, error)
+	onRemove   func(context.Context, *logical.Request, string, map[string]interface{}) error
+	onVerify   func(context.Context, *logical.Request, string, map[string]interface{}) (GrantState, error)
+	onSnapshot func(context.Context, *logical.Request, string, json.RawMessage) (map[string]interface{}, error)
+	onRollback func(context.Context, *logical.Request, string, json.RawMessage, map[string]interface{}) error
 }
 
 func NewCallbackArray(
-	onAppend func(context.Context, *logical.Request, string) (map[string]interface{}, error),
+	onAppend func(context.Context, *logical.Request, string, json.RawMessage) (map[string]interface{}, error),
 	onRemove func(context.Context, *logical.Request, string, map[string]interface{}) error,
+	onVerify func(context.Context, *logical.Request, string, map[string]interface{}) (GrantState, error),
+	onSnapshot func(context.Context, *logical.Request, string, json.RawMessage) (map[string]interface{}, error),
+	onRollback func(context.Context, *logical.Request, string, json.RawMessage, map[string]interface{}) error,
 ) *CallbackArray {
 	return &CallbackArray{
-		onAppend: onAppend,
-		onRemove: onRemove,
+		onAppend:   onAppend,
+		onRemove:   onRemove,
+		onVerify:   onVerify,
+		onSnapshot: onSnapshot,
+		onRollback: onRollback,
 	}
 }
 
 // Append runs the callback that grants access for an element.
-func (m *CallbackArray) Append(ctx context.Context, req *logical.Request, element string) (map[string]interface{}, error) {
+// 'access' is the access configuration approved for the element (nil if not snapshotted).
+func (m *CallbackArray) Append(ctx context.Context, req *logical.Request, element string, access json.RawMessage) (map[string]interface{}, error) {
 	if m.onAppend == nil {
 		return nil, fmt.Errorf("No Append Callback defined")
 	}
-	return m.onAppend(ctx, req, element)
+	return m.onAppend(ctx, req, element, access)
 }
 
 // Remove runs the idempotent callback that revokes access for an element.
+// It must remove only the access granted by the claim (as described by 'internalData'),
+// from the current state, keeping any access assigned to the element since.
 // Revocation must not depend on process-local state because Vault may invoke it
 // after a plugin restart or route it to another plugin process.
 func (m *CallbackArray) Remove(ctx context.Context, req *logical.Request, element string, internalData map[string]interface{}) (bool, error) {
@@ -52,3 +89,33 @@ func (m *CallbackArray) Remove(ctx context.Context, req *logical.Request, elemen
 	}
 	return true, nil
 }
+
+// Verify runs the callback that checks whether the access granted for an element
+// (as described by 'internalData') is in place, without changing it.
+func (m *CallbackArray) Verify(ctx context.Context, req *logical.Request, element string, internalData map[string]interface{}) (GrantState, error) {
+	if m.onVerify == nil {
+		return GrantAbsent, fmt.Errorf("No Verify Callback defined")
+	}
+	return m.onVerify(ctx, req, element, internalData)
+}
+
+// Snapshot runs the callback that records the access an element holds before 'access' is granted to it by Append
+// (e.g.: its policies), without changing it. It is kept with the claim's WAL entry, to be passed to Rollback.
+func (m *CallbackArray) Snapshot(ctx context.Context, req *logical.Request, element string, access json.RawMessage) (map[string]interface{}, error) {
+	if m.onSnapshot == nil {
+		return nil, fmt.Errorf("No Snapshot Callback defined")
+	}
+	return m.onSnapshot(ctx, req, element, access)
+}
+
+// Rollback runs the idempotent callback that revokes access for an element
+// that may have been (partially) granted by an Append that did not complete.
+// Only 'access' (as passed to Append) is known, as the data returned by Append may have been lost.
+// Access held before the Append (as recorded by Snapshot in 'previous') must be kept.
+// 'previous' is nil for claims recorded without a Snapshot, in which case access granted atomically is kept.
+func (m *CallbackArray) Rollback(ctx context.Context, req *logical.Request, element string, access json.RawMessage, previous map[string]interface{}) error {
+	if m.onRollback == nil {
+		return fmt.Errorf("No Rollback Callback defined")
+	}
+	return m.onRollback(ctx, req, element, access, previous)
+}
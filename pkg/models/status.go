@@ -54,12 +54,20 @@ func (s *AccessRequestStatus) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	statusString = capitalizeFirstLetter(statusString)
+	status, err := ParseAccessRequestStatus(statusString)
+	if err != nil {
+		return err
+	}
+	*s = status
+	return nil
+}
+
+func ParseAccessRequestStatus(statusString string) (AccessRequestStatus, error) {
+	statusString = capitalizeFirstLetter(strings.ToLower(statusString))
 	for i, validStatus := range AccessRequestStatusStrings {
 		if statusString == validStatus {
-			*s = AccessRequestStatus(i)
-			return nil
+			return AccessRequestStatus(i), nil
 		}
 	}
-	return fmt.Errorf("invalid AccessRequestStatus: %s", statusString)
+	return Pending, fmt.Errorf("invalid AccessRequestStatus: %s", statusString)
 }
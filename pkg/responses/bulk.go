@@ -0,0 +1,26 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package responses
+
+type BulkItemResponse struct {
+	Success bool `json:"success"`
+	// The status of the AccessRequest after the operation
+	Status   string   `json:"status,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type BulkResponse struct {
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	// RequestorID to the result of the operation on its AccessRequest
+	Results map[string]BulkItemResponse `json:"results"`
+}
@@ -11,7 +11,9 @@
 package responses
 
 import (
-// "time"
+	// "time"
+
+	"github.com/gateplane-io/vault-plugins/pkg/models"
 )
 
 type ConfigResponse struct {
@@ -22,4 +24,73 @@ type ConfigResponse struct {
 	// Unix Time
 	RequestTTL  float64 `json:"request_ttl"`
 	DeleteAfter float64 `json:"delete_after"`
+
+	EncryptRequests bool `json:"encrypt_requests"`
+
+	ProtectConfig           bool     `json:"protect_config"`
+	ConfigRequiredApprovals int      `json:"config_required_approvals"`
+	ConfigApprovers         []string `json:"config_approvers"`
+
+	ResetApprovalsOnAccessChange bool   `json:"reset_approvals_on_access_change"`
+	OnConfigChange               string `json:"on_config_change"`
+
+	ReconcileInterval float64 `json:"reconcile_interval"`
+
+	EntityMetadataKeys []string `json:"entity_metadata_keys"`
+}
+
+type ConfigChangeResponse struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+type ConfigBundleResponse struct {
+	// Configuration path to its values
+	Configuration map[string]map[string]interface{} `json:"configuration"`
+}
+
+type ConfigBundleApplyResponse struct {
+	Applied bool `json:"applied"`
+	// Configuration path to its changed keys
+	Diff map[string]map[string]ConfigChangeResponse `json:"diff"`
+}
+
+type ConfigVersionResponse struct {
+	Version  int    `json:"version"`
+	EntityID string `json:"entity_id"`
+	// Unix Time
+	CreatedAt  int64 `json:"iat"`
+	RollbackOf int   `json:"rollback_of,omitempty"`
+
+	Config map[string]interface{} `json:"config"`
+}
+
+type ConfigHistoryResponse struct {
+	Path           string                  `json:"path"`
+	CurrentVersion int                     `json:"current_version"`
+	Versions       []ConfigVersionResponse `json:"versions"`
+}
+
+type ConfigProposalResponse struct {
+	ID      string `json:"id"`
+	OwnerID string `json:"owner_id"`
+
+	// Unix Time
+	CreatedAt  int64 `json:"iat"`
+	Expiration int64 `json:"exp"`
+	Deletion   int64 `json:"deleted_after"`
+
+	Status            models.AccessRequestStatus `json:"status"`
+	RequiredApprovals int                        `json:"required_approvals"`
+	NumOfApprovals    int                        `json:"num_of_approvals"`
+	HaveApproved      bool                       `json:"have_approved"`
+
+	WithdrawnBy string `json:"withdrawn_by,omitempty"`
+	// Unix Time
+	WithdrawnAt int64 `json:"withdrawn_at,omitempty"`
+
+	// Configuration path to its changed keys
+	Diff map[string]map[string]ConfigChangeResponse `json:"diff"`
+	// Configuration path to the version the ConfigProposal changes
+	BaseVersions map[string]int `json:"base_versions"`
 }
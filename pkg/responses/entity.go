@@ -0,0 +1,25 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package responses
+
+// EntityResponse describes a Vault/OpenBao Entity, alongside its ID
+type EntityResponse struct {
+	ID      string                `json:"id"`
+	Name    string                `json:"name"`
+	Aliases []EntityAliasResponse `json:"aliases"`
+	// Only the keys under 'entity_metadata_keys' (under '/config')
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type EntityAliasResponse struct {
+	Name      string `json:"name"`
+	MountType string `json:"mount_type"`
+}
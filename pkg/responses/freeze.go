@@ -0,0 +1,37 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package responses
+
+type FreezeEventResponse struct {
+	Action string `json:"action"`
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+	// Unix Time
+	CreatedAt int64 `json:"iat"`
+	Revoked   int   `json:"revoked,omitempty"`
+}
+
+type FreezeResponse struct {
+	Frozen  bool                  `json:"frozen"`
+	Message string                `json:"message"`
+	Events  []FreezeEventResponse `json:"events"`
+}
+
+type KillResponse struct {
+	FreezeResponse
+
+	// Requestor IDs of the revoked claims
+	Revoked []string `json:"revoked"`
+	// Requestor IDs of the claims whose removal is retried under '/revocations/pending'
+	Queued []string `json:"queued"`
+	// Requestor IDs to the errors of the claims that could not be revoked
+	Failed map[string]string `json:"failed"`
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package responses
+
+type MigrationRecordResponse struct {
+	Version     int    `json:"version"`
+	Description string `json:"description"`
+	AppliedAt   int64  `json:"applied_at"`
+	Error       string `json:"error,omitempty"`
+}
+
+type MigrationStatusResponse struct {
+	SchemaVersion       int                       `json:"schema_version"`
+	LatestSchemaVersion int                       `json:"latest_schema_version"`
+	UpToDate            bool                      `json:"up_to_date"`
+	LastRun             int64                     `json:"last_run"`
+	Records             []MigrationRecordResponse `json:"records"`
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package responses
+
+import (
+	"github.com/gateplane-io/vault-plugins/pkg/models"
+)
+
+type ReconcileDiscrepancyResponse struct {
+	RequestorID string                     `json:"requestor_id"`
+	Status      models.AccessRequestStatus `json:"status"`
+	Grant       string                     `json:"grant"`
+	Action      string                     `json:"action"`
+	Error       string                     `json:"error,omitempty"`
+}
+
+type ReconcileReportResponse struct {
+	// Unix Time
+	RunAt         int64                          `json:"run_at"`
+	Checked       int                            `json:"checked"`
+	Discrepancies []ReconcileDiscrepancyResponse `json:"discrepancies"`
+}
@@ -27,16 +27,25 @@ type AccessRequestCreationResponse struct {
 
 	Status models.AccessRequestStatus `json:"status"`
 
-	NumOfApprovals int           `json:"num_of_approvals"`
-	Overwrite      bool          `json:"overwrite"`
-	ClaimTTL       time.Duration `json:"claim_ttl"`
+	NumOfApprovals int  `json:"num_of_approvals"`
+	Overwrite      bool `json:"overwrite"`
+	// Number of seconds
+	ClaimTTL time.Duration `json:"claim_ttl"`
+
+	// Configuration path to its version
+	ConfigVersions map[string]int `json:"config_versions"`
+	// The access configuration granted on claim
+	Access map[string]interface{} `json:"access,omitempty"`
 }
 
 type AccessRequestResponse struct {
-	OwnerID    string `json:"requestor_id"`
-	CreatedAt  int64  `json:"iat"`
-	Expiration int64  `json:"exp"`
-	Deletion   int64  `json:"deleted_after"`
+	OwnerID string `json:"requestor_id"`
+	// Resolved from 'requestor_id' (if the entity exists)
+	Requestor *EntityResponse `json:"requestor,omitempty"`
+
+	CreatedAt  int64 `json:"iat"`
+	Expiration int64 `json:"exp"`
+	Deletion   int64 `json:"deleted_after"`
 
 	Justification     string `json:"justification"`
 	RequiredApprovals int    `json:"required_approvals"`
@@ -46,6 +55,80 @@ type AccessRequestResponse struct {
 	NumOfApprovals int  `json:"num_of_approvals"`
 	HaveApproved   bool `json:"have_approved"`
 
-	ClaimCreatedAt int64         `json:"claim_iat"`
-	ClaimTTL       time.Duration `json:"claim_ttl"`
+	RejectedBy      string `json:"rejected_by,omitempty"`
+	RejectionReason string `json:"rejection_reason,omitempty"`
+
+	History  []RequestEventResponse   `json:"history,omitempty"`
+	Comments []RequestCommentResponse `json:"comments,omitempty"`
+
+	ClaimCreatedAt int64 `json:"claim_iat"`
+	// Number of seconds
+	ClaimTTL time.Duration `json:"claim_ttl"`
+	// Number of seconds, limited by the TTLs of the approvals
+	EffectiveClaimTTL time.Duration `json:"effective_claim_ttl"`
+	// Set by the Gate as 'claim_id' on the data of the claim lease (not the lease ID)
+	ClaimID string `json:"claim_id,omitempty"`
+	// Set once the claim lease is revoked
+	LeaseID string `json:"lease_id,omitempty"`
+
+	// Why and by whom the claim was revoked
+	RevocationReason  string `json:"revocation_reason,omitempty"`
+	RevokedBy         string `json:"revoked_by,omitempty"`
+	RevocationComment string `json:"revocation_comment,omitempty"`
+
+	// Configuration path to its version
+	ConfigVersions map[string]int `json:"config_versions"`
+	// The access configuration granted on claim
+	Access map[string]interface{} `json:"access,omitempty"`
+}
+
+type AccessRequestInboxResponse struct {
+	AccessRequestResponse
+
+	// Number of seconds
+	Age           int64 `json:"age"`
+	TimeRemaining int64 `json:"time_remaining"`
+}
+
+type ActiveClaimResponse struct {
+	OwnerID string `json:"requestor_id"`
+	// Set by the Gate as 'claim_id' on the data of the claim lease (not the lease ID)
+	ClaimID string `json:"claim_id"`
+	// Unix Times
+	ClaimCreatedAt  int64 `json:"claim_iat"`
+	ClaimExpiration int64 `json:"claim_exp"`
+	// Number of seconds
+	TimeRemaining int64 `json:"time_remaining"`
+
+	// The data of the granted access
+	ClaimData map[string]interface{} `json:"claim_data"`
+	// Set if the claim is being revoked
+	RevocationReason string `json:"revocation_reason,omitempty"`
+}
+
+type RequestEventResponse struct {
+	Action  string `json:"action"`
+	ActorID string `json:"actor_id"`
+	// Unix Time
+	CreatedAt int64 `json:"iat"`
+}
+
+type RequestCommentResponse struct {
+	AuthorID string `json:"author_id"`
+	// 'requestor' or 'approver'
+	Role    string `json:"role"`
+	Comment string `json:"comment"`
+	// Unix Time
+	CreatedAt int64 `json:"iat"`
+}
+
+type ApprovalResponse struct {
+	OwnerID string `json:"requestor_id"`
+	// The approving entity, resolved from 'requestor_id' (if it exists)
+	Approver *EntityResponse `json:"approver,omitempty"`
+	// Unix Time
+	CreatedAt int64  `json:"iat"`
+	Comment   string `json:"comment,omitempty"`
+	// Number of seconds (0 for no limit)
+	TTL time.Duration `json:"ttl"`
 }
@@ -0,0 +1,28 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package responses
+
+type PendingRevocationResponse struct {
+	ID          string `json:"id"`
+	RequestorID string `json:"requestor_id"`
+	// Unix Times
+	CreatedAt   int64 `json:"iat"`
+	LastAttempt int64 `json:"last_attempt"`
+	NextAttempt int64 `json:"next_attempt"`
+
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error"`
+}
+
+type RevocationRetryResponse struct {
+	Completed []string                    `json:"completed"`
+	Pending   []PendingRevocationResponse `json:"pending"`
+}
@@ -0,0 +1,18 @@
+// Copyright (C) 2025 Ioannis Torakis <john.torakis@gmail.com>
+// SPDX-License-Identifier: Elastic-2.0
+//
+// Licensed under the Elastic License 2.0.
+// You may obtain a copy of the license at:
+// https://www.elastic.co/licensing/elastic-license
+//
+// Use, modification, and redistribution permitted under the terms of the license,
+// except for providing this software as a commercial service or product.
+
+package responses
+
+type StatsResponse struct {
+	// Claimed access that could not be removed yet (should be 0)
+	PendingRevocations int `json:"pending_revocations"`
+	// Unix Time of the oldest pending revocation (0 if none)
+	OldestPendingRevocation int64 `json:"oldest_pending_revocation"`
+}
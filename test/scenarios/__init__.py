@@ -24,11 +24,29 @@ VAULT_URLS = {
         ep: VAULT_API + f"/{mount}/{ep}"
         for ep in [
             "request",
+            "request/comments",
             "approve",
-            "claim",  # usage
+            "reject",
+            "claim",
+            "inbox",
+            "active",
+            "revoke",
+            "release",
+            "freeze",
+            "unfreeze",
+            "kill",
+            "health",
+            "reconcile",
+            "stats",
+            "revocations/pending",
+            "revocations/retry",  # usage
             "config",
             "config/lease",
-            "config/access",  # configuration
+            "config/access",
+            "config/bundle",
+            "config/history",
+            "config/rollback",
+            "config/proposal",  # configuration
         ]
     }
     for mount in ("mock", "pgate", "oktagate")
@@ -7,6 +7,7 @@ from scenarios import (
     approval_scenario,
     configure_plugin,
     get_token_for,
+    revoke_plugin_claim_leases,
     vault_api_request,
 )
 
@@ -123,7 +124,9 @@ class TestMock:
             VAULT_URLS["mock"]["request"], token=user, method="GET"
         )
 
-        assert "revoked" == request_raw["data"]["status"]
+        # Revocations outside of the Gate are lease expiries
+        assert "expired" == request_raw["data"]["status"]
+        assert "expired" == request_raw["data"]["revocation_reason"]
         # Reset TTL
         configure_plugin(
             "mock",
@@ -202,3 +205,649 @@ class TestMock:
 
         assert "keys" in output["data"]
         assert 200 == status
+
+    def test_inbox(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        token = get_token_for(tf_output, gatekeeper=False)
+        gtkpr_token = get_token_for(tf_output, gatekeeper=True)
+
+        configure_plugin("mock", {"required_approvals": 1})
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="POST"
+        )
+        assert 200 == status
+        request_id = output["data"]["requestor_id"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["inbox"], token=gtkpr_token, method="LIST"
+        )
+        assert 200 == status
+        assert request_id in output["data"]["keys"]
+        assert output["data"]["key_info"][request_id]["time_remaining"] > 0
+
+        # Requestors do not see their own AccessRequests
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["inbox"], token=token, method="LIST"
+        )
+        assert request_id not in output.get("data", {}).get("keys", [])
+
+        status, output = vault_api_request(
+            f"{VAULT_URLS['mock']['approve']}/{request_id}",
+            token=gtkpr_token,
+            method="POST",
+        )
+        assert 200 == status
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["inbox"], token=gtkpr_token, method="LIST"
+        )
+        assert request_id not in output.get("data", {}).get("keys", [])
+
+    def test_config_validation(self, setup_vault_resources):
+        # 'lease' longer than 'lease_max' is rejected
+        status, output = configure_plugin(
+            "mock",
+            {"lease": "2h", "lease_max": "1h"},
+            url=VAULT_URLS["mock"]["config/lease"],
+        )
+        assert 400 == status, output
+
+        status, output = configure_plugin("mock", {"required_approvals": -1})
+        assert 400 == status, output
+
+        # 'dry_run' returns the would-be configuration without storing it
+        status, output = configure_plugin(
+            "mock", {"required_approvals": 5, "dry_run": True}
+        )
+        assert 200 == status, output
+        assert 5 == output["data"]["required_approvals"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["config"], token=VAULT_TOKEN_ROOT, method="GET"
+        )
+        assert 5 != output["data"]["required_approvals"]
+
+    def test_config_history_rollback(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        token = get_token_for(tf_output, gatekeeper=False)
+
+        status, output = configure_plugin("mock", {"required_approvals": 3})
+        assert 204 == status, output
+        status, output = configure_plugin("mock", {"required_approvals": 1})
+        assert 204 == status, output
+
+        status, history = vault_api_request(
+            f"{VAULT_URLS['mock']['config/history']}/config",
+            token=VAULT_TOKEN_ROOT,
+            method="GET",
+        )
+        assert 200 == status, history
+        versions = history["data"]["versions"]
+        assert 1 == versions[-1]["config"]["required_approvals"]
+        assert 3 == versions[-2]["config"]["required_approvals"]
+
+        # AccessRequests record the configuration versions they were created under
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="POST"
+        )
+        assert 200 == status, output
+        current_version = history["data"]["current_version"]
+        assert current_version == output["data"]["config_versions"]["config"]
+
+        status, output = configure_plugin(
+            "mock",
+            {"version": versions[-2]["version"]},
+            url=f"{VAULT_URLS['mock']['config/rollback']}/config",
+        )
+        assert 200 == status, output
+        assert 3 == output["data"]["diff"]["config"]["required_approvals"]["to"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["config"], token=VAULT_TOKEN_ROOT, method="GET"
+        )
+        assert 3 == output["data"]["required_approvals"]
+        configure_plugin("mock", {"required_approvals": 1})
+
+    def test_config_protection(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        proposer_token = get_token_for(tf_output, type="cfgadm", index=0)
+        approver_token = get_token_for(tf_output, type="cfgadm", index=1)
+
+        status, output = vault_api_request(
+            f"{VAULT_API}/auth/token/lookup-self", token=approver_token, method="GET"
+        )
+        approver_id = output["data"]["entity_id"]
+
+        # Protection needs the approvers of configuration changes
+        status, output = configure_plugin("mock", {"protect_config": True})
+        assert 400 == status, output
+
+        # Enabling protection applies immediately
+        status, output = configure_plugin(
+            "mock",
+            {
+                "protect_config": True,
+                "config_required_approvals": 1,
+                "config_approvers": approver_id,
+            },
+        )
+        assert 204 == status, output
+
+        # Writes create ConfigProposals instead of being stored
+        status, output = configure_plugin(
+            "mock", {"required_approvals": 2}, token=proposer_token
+        )
+        assert 200 == status, output
+        proposal_id = output["data"]["id"]
+        assert "pending" == output["data"]["status"]
+        assert 2 == output["data"]["diff"]["config"]["required_approvals"]["to"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["config/proposal"],
+            token=approver_token,
+            method="LIST",
+        )
+        assert 200 == status, output
+        assert proposal_id in output["data"]["keys"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["config"], token=VAULT_TOKEN_ROOT, method="GET"
+        )
+        assert 2 != output["data"]["required_approvals"]
+
+        # Only the proposer can withdraw their ConfigProposal
+        status, output = configure_plugin(
+            "mock", {"required_approvals": 3}, token=proposer_token
+        )
+        assert 200 == status, output
+        withdrawn_id = output["data"]["id"]
+        withdrawn_url = f"{VAULT_URLS['mock']['config/proposal']}/{withdrawn_id}"
+        status, output = vault_api_request(
+            withdrawn_url, token=approver_token, method="DELETE"
+        )
+        assert 403 == status, output
+        status, output = vault_api_request(
+            withdrawn_url, token=proposer_token, method="DELETE"
+        )
+        assert 200 == status, output
+        assert "abandoned" == output["data"]["status"]
+        assert output["data"]["withdrawn_by"]
+
+        # The proposer cannot approve their own ConfigProposal
+        status, output = vault_api_request(
+            f"{VAULT_URLS['mock']['config/proposal']}/{proposal_id}/approve",
+            token=proposer_token,
+            method="POST",
+        )
+        assert 403 == status, output
+
+        # Only the 'config_approvers' can approve ConfigProposals
+        status, output = configure_plugin(
+            "mock", {"required_approvals": 3}, token=approver_token
+        )
+        assert 200 == status, output
+        status, output = vault_api_request(
+            f"{VAULT_URLS['mock']['config/proposal']}/{output['data']['id']}/approve",
+            token=proposer_token,
+            method="POST",
+        )
+        assert 403 == status, output
+
+        status, output = vault_api_request(
+            f"{VAULT_URLS['mock']['config/proposal']}/{proposal_id}/approve",
+            token=approver_token,
+            method="POST",
+        )
+        assert 200 == status, output
+        assert "approved" == output["data"]["status"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["config"], token=VAULT_TOKEN_ROOT, method="GET"
+        )
+        assert 2 == output["data"]["required_approvals"]
+
+        # Disabling protection requires approval as well
+        status, output = configure_plugin(
+            "mock",
+            {"protect_config": False, "required_approvals": 1},
+            token=proposer_token,
+        )
+        assert 200 == status, output
+        status, output = vault_api_request(
+            f"{VAULT_URLS['mock']['config/proposal']}/{output['data']['id']}/approve",
+            token=approver_token,
+            method="POST",
+        )
+        assert 200 == status, output
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["config"], token=VAULT_TOKEN_ROOT, method="GET"
+        )
+        assert not output["data"]["protect_config"]
+        assert 1 == output["data"]["required_approvals"]
+
+    def test_health(self, setup_vault_resources):
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["health"], token=VAULT_TOKEN_ROOT, method="GET"
+        )
+        assert 200 == status, output
+        assert output["data"]["healthy"], output
+        checks = {check["name"]: check for check in output["data"]["checks"]}
+        assert "ok" == checks["config"]["status"]
+
+    def test_reconcile(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        token = get_token_for(tf_output, gatekeeper=False)
+        gtkpr_token = get_token_for(tf_output, gatekeeper=True)
+        approval_scenario("mock", token, [gtkpr_token])
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["reconcile"], token=VAULT_TOKEN_ROOT, method="POST"
+        )
+        assert 200 == status, output
+        assert output["data"]["checked"] >= 1
+        # The active claim of the mock is in place
+        assert not [
+            discrepancy
+            for discrepancy in output["data"]["discrepancies"]
+            if discrepancy["status"] == "active"
+        ]
+
+        status, report = vault_api_request(
+            VAULT_URLS["mock"]["reconcile"], token=VAULT_TOKEN_ROOT, method="GET"
+        )
+        assert output["data"]["run_at"] == report["data"]["run_at"]
+
+    def test_revocations(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        token = get_token_for(tf_output, gatekeeper=False)
+        gtkpr_token = get_token_for(tf_output, gatekeeper=True)
+        approval_scenario("mock", token, [gtkpr_token])
+        revoke_plugin_claim_leases("mock")
+
+        # The mock always removes its access, so nothing is left to retry
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["stats"], token=VAULT_TOKEN_ROOT, method="GET"
+        )
+        assert 200 == status, output
+        assert 0 == output["data"]["pending_revocations"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["revocations/retry"],
+            token=VAULT_TOKEN_ROOT,
+            method="POST",
+        )
+        assert 200 == status, output
+        assert [] == output["data"]["pending"]
+
+    def test_admin_revoke(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        token = get_token_for(tf_output, gatekeeper=False)
+        gtkpr_token = get_token_for(tf_output, gatekeeper=True)
+        request = approval_scenario("mock", token, [gtkpr_token])
+        request_id = request["request"]["requestor_id"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["active"], token=VAULT_TOKEN_ROOT, method="LIST"
+        )
+        assert 200 == status, output
+        assert request_id in output["data"]["keys"]
+        active = output["data"]["key_info"][request_id]
+        assert active["claim_data"]["claimed"]
+        assert active["claim_id"] == request["claim"]["data"]["claim_id"]
+        assert active["time_remaining"] > 0
+
+        # A reason is required
+        status, output = vault_api_request(
+            f"{VAULT_URLS['mock']['revoke']}/{request_id}",
+            token=VAULT_TOKEN_ROOT,
+            method="POST",
+        )
+        assert 400 == status, output
+
+        status, output = vault_api_request(
+            f"{VAULT_URLS['mock']['revoke']}/{request_id}",
+            token=VAULT_TOKEN_ROOT,
+            method="POST",
+            data={"reason": "incident response"},
+        )
+        assert 200 == status, output
+        assert "revoked" == output["data"]["status"]
+        assert "admin" == output["data"]["revocation_reason"]
+        assert "incident response" == output["data"]["revocation_comment"]
+        # The lease is not revoked by the Gate
+        assert any("lease" in warning for warning in output["warnings"])
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["active"], token=VAULT_TOKEN_ROOT, method="LIST"
+        )
+        assert request_id not in output.get("data", {}).get("keys", [])
+
+        # The lease finds its access already removed
+        revoke_plugin_claim_leases("mock")
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="GET"
+        )
+        assert "revoked" == output["data"]["status"]
+
+    def test_release(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        token = get_token_for(tf_output, gatekeeper=False)
+        gtkpr_token = get_token_for(tf_output, gatekeeper=True)
+        request = approval_scenario("mock", token, [gtkpr_token])
+        request_id = request["request"]["requestor_id"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["release"],
+            token=token,
+            method="POST",
+            data={"reason": "done"},
+        )
+        assert 200 == status, output
+        assert "revoked" == output["data"]["status"]
+        assert "released" == output["data"]["revocation_reason"]
+        assert request_id == output["data"]["revoked_by"]
+        assert "done" == output["data"]["revocation_comment"]
+
+        # Only active claims can be released
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["release"], token=token, method="POST"
+        )
+        assert 400 == status, output
+
+        # The lease finds its access already removed
+        revoke_plugin_claim_leases("mock")
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="GET"
+        )
+        assert "revoked" == output["data"]["status"]
+        assert "released" == output["data"]["revocation_reason"]
+
+    def test_freeze_and_kill(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        token = get_token_for(tf_output, gatekeeper=False)
+        gtkpr_token = get_token_for(tf_output, gatekeeper=True)
+        request = approval_scenario("mock", token, [gtkpr_token])
+        request_id = request["request"]["requestor_id"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["freeze"],
+            token=VAULT_TOKEN_ROOT,
+            method="POST",
+            data={"reason": "suspected compromise", "message": "Frozen for IR"},
+        )
+        assert 200 == status, output
+        assert output["data"]["frozen"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="POST"
+        )
+        assert 403 == status, output
+        assert "Frozen for IR" in output["errors"][0]
+
+        # Active claims keep their access until killed
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["kill"],
+            token=VAULT_TOKEN_ROOT,
+            method="POST",
+            data={"reason": "confirmed compromise"},
+        )
+        assert 200 == status, output
+        assert request_id in output["data"]["revoked"]
+        assert "kill" == output["data"]["events"][-1]["action"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="GET"
+        )
+        assert "revoked" == output["data"]["status"]
+        assert "kill" == output["data"]["revocation_reason"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["unfreeze"],
+            token=VAULT_TOKEN_ROOT,
+            method="POST",
+            data={"reason": "resolved"},
+        )
+        assert 200 == status, output
+        assert not output["data"]["frozen"]
+        revoke_plugin_claim_leases("mock")
+
+        approval_scenario("mock", token, [gtkpr_token])
+
+    def test_bulk_approve_and_reject(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        token = get_token_for(tf_output, gatekeeper=False)
+        gtkpr_token = get_token_for(tf_output, gatekeeper=True)
+        configure_plugin("mock", {"required_approvals": 1})
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="POST"
+        )
+        assert 200 == status, output
+        request_id = output["data"]["requestor_id"]
+
+        # A selection is required
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["approve"], token=gtkpr_token, method="POST"
+        )
+        assert 400 == status, output
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["approve"],
+            token=gtkpr_token,
+            method="POST",
+            data={"requestor_ids": [request_id, "does-not-exist"]},
+        )
+        assert 200 == status, output
+        assert 1 == output["data"]["succeeded"]
+        assert 1 == output["data"]["failed"]
+        assert "approved" == output["data"]["results"][request_id]["status"]
+        assert not output["data"]["results"]["does-not-exist"]["success"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["reject"],
+            token=gtkpr_token,
+            method="POST",
+            data={"requestor_ids": [request_id], "reason": "stale"},
+        )
+        assert 200 == status, output
+        assert output["data"]["results"][request_id]["success"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="GET"
+        )
+        assert "rejected" == output["data"]["status"]
+        assert "stale" == output["data"]["rejection_reason"]
+
+    def test_retract_approval(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        token = get_token_for(tf_output, gatekeeper=False)
+        gtkpr_token = get_token_for(tf_output, gatekeeper=True)
+        configure_plugin("mock", {"required_approvals": 1})
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="POST"
+        )
+        assert 200 == status, output
+        request_id = output["data"]["requestor_id"]
+        approve_url = f"{VAULT_URLS['mock']['approve']}/{request_id}"
+
+        status, output = vault_api_request(
+            approve_url, token=gtkpr_token, method="POST"
+        )
+        assert 200 == status, output
+        assert "approved" == output["data"]["status"]
+
+        # The quorum is lost
+        status, output = vault_api_request(
+            approve_url, token=gtkpr_token, method="DELETE"
+        )
+        assert 200 == status, output
+        assert "pending" == output["data"]["status"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="GET"
+        )
+        assert 0 == output["data"]["num_of_approvals"]
+        assert "approval_retracted" == output["data"]["history"][-1]["action"]
+
+        # Nothing left to retract
+        status, output = vault_api_request(
+            approve_url, token=gtkpr_token, method="DELETE"
+        )
+        assert 400 == status, output
+
+    def test_approval_comment_and_ttl(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        token = get_token_for(tf_output, gatekeeper=False)
+        gtkpr_token = get_token_for(tf_output, gatekeeper=True)
+        configure_plugin("mock", {"required_approvals": 1})
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="POST"
+        )
+        assert 200 == status, output
+        request_id = output["data"]["requestor_id"]
+        approve_url = f"{VAULT_URLS['mock']['approve']}/{request_id}"
+
+        status, output = vault_api_request(
+            approve_url,
+            data={"comment": "ok for INC-1234 only", "ttl": "2m"},
+            token=gtkpr_token,
+            method="POST",
+        )
+        assert 200 == status, output
+
+        status, output = vault_api_request(
+            approve_url, token=gtkpr_token, method="LIST"
+        )
+        assert 200 == status, output
+        approval = list(output["data"]["key_info"].values())[0]
+        assert "ok for INC-1234 only" == approval["comment"]
+        assert 120 == approval["ttl"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="GET"
+        )
+        assert 120 == output["data"]["effective_claim_ttl"]
+
+        # The claim is limited by the approval
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["claim"], token=token, method="POST"
+        )
+        assert 200 == status, output
+        assert output["lease_duration"] <= 120
+
+    def test_request_comments(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        token = get_token_for(tf_output, gatekeeper=False)
+        gtkpr_token = get_token_for(tf_output, gatekeeper=True)
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="POST"
+        )
+        assert 200 == status, output
+        request_id = output["data"]["requestor_id"]
+        comments_url = f"{VAULT_URLS['mock']['request/comments']}/{request_id}"
+
+        status, output = vault_api_request(
+            comments_url,
+            data={"comment": "why do you need write and not read?"},
+            token=gtkpr_token,
+            method="POST",
+        )
+        assert 200 == status, output
+        assert "approver" == output["data"]["role"]
+
+        status, output = vault_api_request(
+            comments_url,
+            data={"comment": "to rotate the object"},
+            token=token,
+            method="POST",
+        )
+        assert 200 == status, output
+        assert "requestor" == output["data"]["role"]
+        assert request_id == output["data"]["author_id"]
+
+        # Empty comments are refused
+        status, output = vault_api_request(
+            comments_url, data={"comment": " "}, token=token, method="POST"
+        )
+        assert 400 == status, output
+
+        # Overlong comments are refused
+        status, output = vault_api_request(
+            comments_url, data={"comment": "a" * 4097}, token=token, method="POST"
+        )
+        assert 400 == status, output
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="GET"
+        )
+        assert ["approver", "requestor"] == [
+            c["role"] for c in output["data"]["comments"]
+        ]
+        assert "comment_added" == output["data"]["history"][-1]["action"]
+
+        status, output = vault_api_request(
+            comments_url, token=gtkpr_token, method="GET"
+        )
+        assert 200 == status, output
+        assert 2 == len(output["data"]["comments"])
+
+        # Terminated AccessRequests are not commented on
+        status, output = vault_api_request(
+            f"{VAULT_URLS['mock']['reject']}/{request_id}",
+            token=gtkpr_token,
+            method="POST",
+        )
+        assert 200 == status, output
+        status, output = vault_api_request(
+            comments_url,
+            data={"comment": "why?"},
+            token=token,
+            method="POST",
+        )
+        assert 400 == status, output
+
+    def test_entity_names(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        token = get_token_for(tf_output, gatekeeper=False)
+        gtkpr_token = get_token_for(tf_output, gatekeeper=True)
+        configure_plugin("mock", {"required_approvals": 1})
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="POST"
+        )
+        assert 200 == status, output
+        request_id = output["data"]["requestor_id"]
+
+        status, output = vault_api_request(
+            f"{VAULT_API}/identity/entity/id/{request_id}",
+            token=VAULT_TOKEN_ROOT,
+            method="GET",
+        )
+        assert 200 == status, output
+        entity_name = output["data"]["name"]
+
+        status, output = vault_api_request(
+            VAULT_URLS["mock"]["request"], token=token, method="GET"
+        )
+        requestor = output["data"]["requestor"]
+        assert request_id == requestor["id"]
+        assert entity_name == requestor["name"]
+
+        approve_url = f"{VAULT_URLS['mock']['approve']}/{request_id}"
+        status, output = vault_api_request(
+            approve_url, token=gtkpr_token, method="POST"
+        )
+        assert 200 == status, output
+
+        status, output = vault_api_request(
+            approve_url, token=gtkpr_token, method="LIST"
+        )
+        assert 200 == status, output
+        approver_id = output["data"]["keys"][0]
+        approver = output["data"]["key_info"][approver_id]["approver"]
+        assert approver_id == approver["id"]
+        assert approver["name"]
@@ -6,6 +6,7 @@ from scenarios import (
     vault_api_request,
     VAULT_URLS,
     VAULT_API,
+    VAULT_TOKEN_ROOT,
 )
 
 
@@ -34,3 +35,232 @@ class TestPolicyGate:
         assert 200 == status
         identity_policies = entity["data"]["identity_policies"]
         assert all([policy in identity_policies for policy in new_policies])
+
+    def test_config_bundle(self, setup_vault_resources):
+        status, bundle = vault_api_request(
+            VAULT_URLS["pgate"]["config/bundle"], token=VAULT_TOKEN_ROOT, method="GET"
+        )
+        assert 200 == status, bundle
+        configuration = bundle["data"]["configuration"]
+        assert "config/access" in configuration
+        assert "config/api/vault" in configuration
+        # Write-only values are never returned
+        assert "role_secret" not in configuration["config/api/vault"]
+
+        new_policies = [randomword() for i in range(2)]
+        status, output = configure_plugin(
+            "pgate",
+            {
+                "configuration": {"config/access": {"policies": new_policies}},
+                "dry_run": True,
+            },
+            url=VAULT_URLS["pgate"]["config/bundle"],
+        )
+        assert 200 == status, output
+        assert not output["data"]["applied"]
+        assert new_policies == output["data"]["diff"]["config/access"]["policies"]["to"]
+
+        # Sections are validated together and nothing is stored on failure
+        status, output = configure_plugin(
+            "pgate",
+            {
+                "configuration": {
+                    "config/access": {"policies": new_policies},
+                    "config/lease": {"lease": "2h", "lease_max": "1h"},
+                }
+            },
+            url=VAULT_URLS["pgate"]["config/bundle"],
+        )
+        assert 400 == status, output
+        status, output = vault_api_request(
+            VAULT_URLS["pgate"]["config/access"], token=VAULT_TOKEN_ROOT, method="GET"
+        )
+        assert new_policies != output["data"]["policies"]
+
+    def test_access_snapshot(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        user = get_token_for(tf_output, gatekeeper=False)
+        gtkpr = get_token_for(tf_output, gatekeeper=True)
+
+        approved_policies = [randomword() for i in range(2)]
+        configure_plugin(
+            "pgate",
+            {"policies": approved_policies},
+            url=VAULT_URLS["pgate"]["config/access"],
+        )
+        configure_plugin("pgate", {"required_approvals": 1})
+
+        status, output = vault_api_request(
+            VAULT_URLS["pgate"]["request"], token=user, method="POST"
+        )
+        assert 200 == status, output
+        assert approved_policies == output["data"]["access"]["policies"]
+        requestor_id = output["data"]["requestor_id"]
+
+        status, output = vault_api_request(
+            f"{VAULT_URLS['pgate']['approve']}/{requestor_id}",
+            token=gtkpr,
+            method="POST",
+        )
+        assert 200 == status, output
+
+        # Changing the access configuration after approval does not change what is granted
+        configure_plugin(
+            "pgate",
+            {"policies": [randomword()]},
+            url=VAULT_URLS["pgate"]["config/access"],
+        )
+        status, output = vault_api_request(
+            VAULT_URLS["pgate"]["claim"], token=user, method="POST"
+        )
+        assert 200 == status, output
+        assert approved_policies == output["data"]["new_policies"]
+
+    def test_on_config_change_revoke(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        user = get_token_for(tf_output, gatekeeper=False)
+        gtkpr = get_token_for(tf_output, gatekeeper=True)
+
+        granted_policies = [randomword() for i in range(2)]
+        configure_plugin(
+            "pgate",
+            {"policies": granted_policies},
+            url=VAULT_URLS["pgate"]["config/access"],
+        )
+        status, output = configure_plugin("pgate", {"on_config_change": "revoke"})
+        assert 204 == status, output
+
+        approval_scenario("pgate", user, [gtkpr])
+
+        status, output = configure_plugin(
+            "pgate",
+            {"policies": [randomword()]},
+            url=VAULT_URLS["pgate"]["config/access"],
+        )
+        assert 200 == status, output
+
+        status, output = vault_api_request(
+            VAULT_URLS["pgate"]["request"], token=user, method="GET"
+        )
+        assert "revoked" == output["data"]["status"]
+        assert "config_change" == output["data"]["revocation_reason"]
+
+        status, entity = vault_api_request(
+            f"{VAULT_API}/auth/token/lookup-self", method="GET", token=user
+        )
+        identity_policies = entity["data"].get("identity_policies") or []
+        assert not any([policy in identity_policies for policy in granted_policies])
+        configure_plugin("pgate", {"on_config_change": "keep"})
+
+    def test_reconcile_keeps_assigned_policies(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        user = get_token_for(tf_output, gatekeeper=False)
+        gtkpr = get_token_for(tf_output, gatekeeper=True)
+
+        granted_policies = [randomword() for i in range(2)]
+        configure_plugin(
+            "pgate",
+            {"policies": granted_policies},
+            url=VAULT_URLS["pgate"]["config/access"],
+        )
+        data = approval_scenario("pgate", user, [gtkpr])
+        requestor_id = data["request"]["requestor_id"]
+        entity_url = f"{VAULT_API}/identity/entity/id/{requestor_id}"
+
+        def entity_policies():
+            status, output = vault_api_request(
+                entity_url, token=VAULT_TOKEN_ROOT, method="GET"
+            )
+            assert 200 == status, output
+            return output["data"]["policies"] or []
+
+        def set_entity_policies(policies):
+            status, output = vault_api_request(
+                entity_url,
+                data={"policies": policies},
+                token=VAULT_TOKEN_ROOT,
+                method="POST",
+            )
+            assert status in (200, 204), output
+
+        # A policy is assigned to the entity while the claim is active
+        assigned_policy = randomword()
+        set_entity_policies(entity_policies() + [assigned_policy])
+
+        status, output = vault_api_request(
+            f"{VAULT_API}/sys/leases/revoke",
+            data={"lease_id": data["claim"]["lease_id"]},
+            token=VAULT_TOKEN_ROOT,
+            method="POST",
+        )
+        assert 204 == status, output
+        policies = entity_policies()
+        assert assigned_policy in policies
+        assert not any([policy in policies for policy in granted_policies])
+
+        # A granted policy left in place is removed by reconciliation,
+        # without removing the assigned one
+        set_entity_policies(policies + [granted_policies[0]])
+        status, output = vault_api_request(
+            VAULT_URLS["pgate"]["reconcile"], token=VAULT_TOKEN_ROOT, method="POST"
+        )
+        assert 200 == status, output
+        policies = entity_policies()
+        assert assigned_policy in policies
+        assert granted_policies[0] not in policies
+
+        set_entity_policies([p for p in policies if p != assigned_policy])
+
+    def test_on_config_change_regrant(self, setup_vault_resources):
+        tf_output = setup_vault_resources  # just rename
+        user = get_token_for(tf_output, gatekeeper=False)
+        gtkpr = get_token_for(tf_output, gatekeeper=True)
+
+        granted_policies = [randomword() for i in range(2)]
+        configure_plugin(
+            "pgate",
+            {"policies": granted_policies},
+            url=VAULT_URLS["pgate"]["config/access"],
+        )
+        status, output = configure_plugin("pgate", {"on_config_change": "regrant"})
+        assert 204 == status, output
+
+        data = approval_scenario("pgate", user, [gtkpr])
+        entity_url = f"{VAULT_API}/identity/entity/id/{data['request']['requestor_id']}"
+
+        # A policy is assigned to the entity after the claim
+        status, output = vault_api_request(
+            entity_url, token=VAULT_TOKEN_ROOT, method="GET"
+        )
+        assigned_policy = randomword()
+        status, output = vault_api_request(
+            entity_url,
+            data={"policies": (output["data"]["policies"] or []) + [assigned_policy]},
+            token=VAULT_TOKEN_ROOT,
+            method="POST",
+        )
+        assert status in (200, 204), output
+
+        regranted_policies = [randomword()]
+        status, output = configure_plugin(
+            "pgate",
+            {"policies": regranted_policies},
+            url=VAULT_URLS["pgate"]["config/access"],
+        )
+        assert 200 == status, output
+
+        status, output = vault_api_request(
+            entity_url, token=VAULT_TOKEN_ROOT, method="GET"
+        )
+        policies = output["data"]["policies"] or []
+        assert assigned_policy in policies
+        assert regranted_policies[0] in policies
+        assert not any([policy in policies for policy in granted_policies])
+
+        status, output = vault_api_request(
+            entity_url,
+            data={"policies": [p for p in policies if p != assigned_policy]},
+            token=VAULT_TOKEN_ROOT,
+            method="POST",
+        )
+        configure_plugin("pgate", {"on_config_change": "keep"})
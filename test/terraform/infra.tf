@@ -40,6 +40,64 @@ module "mock" {
   lease_max_ttl = "3h"
 }
 
+// Manages the configuration of the 'mock' mount,
+// used by the tests of protected configuration
+resource "vault_policy" "mock_config" {
+  depends_on = [module.mock]
+  name       = "mock-config-admin"
+  policy     = <<-EOT
+    path "mock/config" {
+      capabilities = ["read", "update"]
+    }
+    path "mock/config/*" {
+      capabilities = ["create", "read", "update", "delete", "list"]
+    }
+  EOT
+}
+
+// Approves and rejects AccessRequests of the 'mock' mount, also in bulk,
+// and retracts approvals
+resource "vault_policy" "mock_bulk" {
+  depends_on = [module.mock]
+  name       = "mock-bulk-approver"
+  policy     = <<-EOT
+    path "mock/approve" {
+      capabilities = ["update"]
+    }
+    path "mock/approve/*" {
+      capabilities = ["update", "delete", "list"]
+    }
+    path "mock/reject" {
+      capabilities = ["update"]
+    }
+    path "mock/reject/*" {
+      capabilities = ["update"]
+    }
+  EOT
+}
+
+// Comments on AccessRequests of the 'mock' mount
+resource "vault_policy" "mock_comments" {
+  depends_on = [module.mock]
+  name       = "mock-commenter"
+  policy     = <<-EOT
+    path "mock/request/comments/*" {
+      capabilities = ["read", "update"]
+    }
+  EOT
+}
+
+// Releases claims of the 'mock' mount
+resource "vault_policy" "mock_release" {
+  depends_on = [module.mock]
+  name       = "mock-releaser"
+  policy     = <<-EOT
+    path "mock/release" {
+      capabilities = ["update"]
+    }
+  EOT
+}
+
 module "access" {
   depends_on = [module.infra]
   source     = "github.com/gateplane-io/terraform-gateplane-policy-gate?ref=1.2.0"
@@ -102,6 +160,8 @@ module "tokens" {
       "policies" = [
         module.access.policy_names["requestor"],
         module.mock.policy_names["requestor"],
+        vault_policy.mock_comments.name,
+        vault_policy.mock_release.name,
       ]
     },
     "gtkpr" = {
@@ -109,6 +169,14 @@ module "tokens" {
       "policies" = [
         module.access.policy_names["approver"],
         module.mock.policy_names["approver"],
+        vault_policy.mock_bulk.name,
+        vault_policy.mock_comments.name,
+      ]
+    },
+    "cfgadm" = {
+      "quantity" = 2,
+      "policies" = [
+        vault_policy.mock_config.name,
       ]
     },
     # "okta" = {